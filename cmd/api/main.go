@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -12,8 +13,11 @@ import (
 	"time"
 
 	"github.com/ifaisalabid1/url-shortener/internal/config"
+	"github.com/ifaisalabid1/url-shortener/internal/graph"
 	"github.com/ifaisalabid1/url-shortener/internal/handler"
+	"github.com/ifaisalabid1/url-shortener/internal/model"
 	"github.com/ifaisalabid1/url-shortener/internal/repository"
+	"github.com/ifaisalabid1/url-shortener/internal/rpc"
 	"github.com/ifaisalabid1/url-shortener/internal/service"
 	_ "github.com/lib/pq"
 	"github.com/redis/go-redis/v9"
@@ -50,10 +54,58 @@ func main() {
 	defer redisClient.Close()
 
 	urlRepo := repository.NewURLRepository(db)
+	aliasRepo := repository.NewAliasRepository(db)
+	moderationRepo := repository.NewModerationRepository(db)
+	historyRepo := repository.NewHistoryRepository(db)
+	auditRepo := repository.NewAuditRepository(db)
+	domainRepo := repository.NewDomainRepository(db)
+	webhookRepo := repository.NewWebhookRepository(db)
 	cacheRepo := repository.NewClientRepository(redisClient)
-	urlService := service.NewURLService(urlRepo, cacheRepo, cfg.App.BaseURL, cfg.App.ShortLength, cfg.App.CacheTTL)
-	urlHandler := handler.NewURLHandler(urlService, logger)
-	router := handler.Routes(urlHandler, logger)
+	normalizer := service.NewNormalizer(cfg.App.Normalize.SortQuery, cfg.App.Normalize.StripParams)
+	policy := service.NewDestinationPolicy(cfg.App.Policy.AllowedSchemes, cfg.App.Policy.AllowedDomains, cfg.App.Policy.DeniedDomains, cfg.App.Policy.BlockPrivateIPs)
+
+	blocklist, err := loadBlocklist(cfg)
+	if err != nil {
+		logger.Error("failed to load blocklists", "error", err)
+		os.Exit(1)
+	}
+
+	domainService := service.NewDomainService(domainRepo, model.DomainFallbacks{
+		NotFoundURL: cfg.App.Pages.NotFoundURL,
+		ExpiredURL:  cfg.App.Pages.ExpiredURL,
+		DisabledURL: cfg.App.Pages.DisabledURL,
+		RootURL:     cfg.App.Pages.RootURL,
+		CatchAllURL: cfg.App.Pages.CatchAllURL,
+	})
+	if err := domainService.Reload(context.Background()); err != nil {
+		logger.Error("failed to load short domains", "error", err)
+		os.Exit(1)
+	}
+
+	webhookService := service.NewWebhookService(webhookRepo, urlRepo, cfg.App.BaseURL)
+	urlService := service.NewURLService(urlRepo, aliasRepo, moderationRepo, historyRepo, cacheRepo, cfg.App.BaseURL, cfg.App.ShortLength, cfg.App.CacheTTL, cfg.App.ReuseExisting, normalizer, policy, blocklist, domainService, webhookService, cfg.App.Interstitial.TrustLevels, cfg.App.Webhook.ClickThresholds)
+	moderationService := service.NewModerationService(urlRepo, aliasRepo, moderationRepo, cacheRepo, cfg.App.BaseURL)
+	pages, err := handler.LoadPages(cfg.App.Pages.Dir)
+	if err != nil {
+		logger.Error("failed to load pages", "error", err)
+		os.Exit(1)
+	}
+
+	urlHandler := handler.NewURLHandler(urlService, domainService, pages, cfg.App.Interstitial.Countdown, logger)
+	moderationHandler := handler.NewModerationHandler(moderationService, logger)
+	auditService := service.NewAuditService(auditRepo)
+	auditHandler := handler.NewAuditHandler(auditService, logger)
+	domainHandler := handler.NewDomainHandler(domainService, logger)
+	webhookHandler := handler.NewWebhookHandler(webhookService, logger)
+	graphServer, err := graph.NewServer(urlService, domainService, cfg.App.GraphQLMaxComplexity, logger)
+	if err != nil {
+		logger.Error("failed to build graphql schema", "error", err)
+		os.Exit(1)
+	}
+
+	graphqlHandler := handler.NewGraphQLHandler(graphServer, logger)
+	idempotencyService := service.NewIdempotencyService(cacheRepo, cfg.App.IdempotencyWindow)
+	router := handler.Routes(urlHandler, moderationHandler, auditHandler, domainHandler, webhookHandler, graphqlHandler, idempotencyService, cfg.App.AdminAPIKey, logger)
 
 	server := &http.Server{
 		Addr:         fmt.Sprintf(":%s", cfg.Server.Port),
@@ -70,7 +122,37 @@ func main() {
 		os.Exit(1)
 	}()
 
-	go startCleanupJob(db, logger)
+	grpcServer := rpc.NewGRPCServer(rpc.NewServer(urlService, domainService, logger), auditService, cfg.App.AdminAPIKey)
+
+	go func() {
+		listener, err := net.Listen("tcp", fmt.Sprintf(":%s", cfg.Server.GRPCPort))
+		if err != nil {
+			logger.Error("grpc server failed to listen", "error", err)
+			os.Exit(1)
+		}
+
+		logger.Info("starting grpc server", "port", cfg.Server.GRPCPort)
+		if err := grpcServer.Serve(listener); err != nil {
+			logger.Error("grpc server failed", "error", err)
+			os.Exit(1)
+		}
+	}()
+
+	go rehashStaleURLs(urlService, logger)
+	go startCleanupJob(urlService, cfg.App.TrashRetention, logger)
+	go startBlocklistReloader(blocklist, cfg.App.Blocklist.ReloadInterval, logger)
+	go startDomainReloader(domainService, cfg.App.DomainReloadInterval, logger)
+
+	dispatcher := service.NewWebhookDispatcher(webhookRepo, service.NewWebhookClient(cfg.App.Webhook.Timeout, policy), cfg.App.Webhook.Concurrency, cfg.App.Webhook.BatchSize, cfg.App.Webhook.MaxAttempts, cfg.App.Webhook.Backoff, cfg.App.Webhook.MaxBackoff)
+
+	go startWebhookDispatcher(webhookService, dispatcher, cfg.App.Webhook.PollInterval, cfg.App.Webhook.BatchSize, logger)
+
+	if cfg.App.HealthCheck.Enabled {
+		client := service.NewHealthCheckClient(cfg.App.HealthCheck.Timeout, policy)
+		checker := service.NewHealthChecker(urlRepo, client, cfg.App.HealthCheck.Concurrency, cfg.App.HealthCheck.HostDelay, cfg.App.HealthCheck.BatchSize, cfg.App.HealthCheck.MaxAge)
+
+		go startHealthCheckJob(checker, cfg.App.HealthCheck.Interval, logger)
+	}
 
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -81,11 +163,24 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
+	grpcStopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(grpcStopped)
+	}()
+
 	if err := server.Shutdown(ctx); err != nil {
 		logger.Error("server forced to shutdown", "error", err)
 		os.Exit(1)
 	}
 
+	select {
+	case <-grpcStopped:
+	case <-ctx.Done():
+		logger.Error("grpc server forced to shutdown")
+		grpcServer.Stop()
+	}
+
 	logger.Info("server stopped")
 
 }
@@ -133,23 +228,160 @@ func connectRedis(cfg *config.Config) (*redis.Client, error) {
 	return client, nil
 }
 
-func startCleanupJob(db *sql.DB, logger *slog.Logger) {
+func loadBlocklist(cfg *config.Config) (*service.Blocklist, error) {
+	var sources []service.BlocklistSource
+
+	for _, raw := range cfg.App.Blocklist.Sources {
+		source, err := service.ParseBlocklistSource(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		sources = append(sources, source)
+	}
+
+	blocklist := service.NewBlocklist(sources)
+	if err := blocklist.Reload(); err != nil {
+		return nil, err
+	}
+
+	return blocklist, nil
+}
+
+func startBlocklistReloader(blocklist *service.Blocklist, interval time.Duration, logger *slog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := blocklist.Reload(); err != nil {
+			logger.Error("failed to reload blocklists", "error", err)
+			continue
+		}
+
+		domains, hashPrefixes := blocklist.Size()
+
+		logger.Info("Blocklists reloaded", "domains", domains, "hash prefixes", hashPrefixes)
+	}
+}
+
+func startDomainReloader(domainService service.DomainService, interval time.Duration, logger *slog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := domainService.Reload(context.Background()); err != nil {
+			logger.Error("failed to reload short domains", "error", err)
+		}
+	}
+}
+
+func startHealthCheckJob(checker *service.HealthChecker, interval time.Duration, logger *slog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		logger.Info("Running destination health check job")
+
+		checked, broken, err := checker.CheckDue(context.Background())
+		if err != nil {
+			logger.Error("failed to check destination health", "error", err)
+			continue
+		}
+
+		logger.Info("Health check job completed", "checked", checked, "broken", broken)
+	}
+}
+
+func startWebhookDispatcher(webhookService service.WebhookService, dispatcher *service.WebhookDispatcher, interval time.Duration, batchSize int, logger *slog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if expired, err := webhookService.NotifyExpired(context.Background(), batchSize); err != nil {
+			logger.Error("failed to publish link expiry webhooks", "error", err)
+		} else if expired > 0 {
+			logger.Info("Published link expiry webhooks", "links", expired)
+		}
+
+		delivered, failed, err := dispatcher.DeliverDue(context.Background())
+		if err != nil {
+			logger.Error("failed to deliver webhooks", "error", err)
+			continue
+		}
+
+		if delivered > 0 || failed > 0 {
+			logger.Info("Webhook deliveries sent", "delivered", delivered, "failed", failed)
+		}
+	}
+}
+
+// rehashStaleURLs brings the hashes of links created before URL
+// normalization in line with new ones, so they can be reused.
+func rehashStaleURLs(urlService service.URLService, logger *slog.Logger) {
+	total := 0
+
+	for {
+		rehashed, err := urlService.RehashStaleURLs(context.Background(), 500)
+		if err != nil {
+			logger.Error("failed to rehash urls", "error", err)
+			return
+		}
+
+		if rehashed == 0 {
+			break
+		}
+
+		total += rehashed
+	}
+
+	if total > 0 {
+		logger.Info("Rehashed urls created before normalization", "urls", total)
+	}
+}
+
+// cleanupBatchSize is how many links the cleanup job deletes per query.
+const cleanupBatchSize = 500
+
+// drain calls batch until it reports nothing left to do, returning the total
+// it handled.
+func drain(batch func() (int, error)) (int, error) {
+	total := 0
+
+	for {
+		n, err := batch()
+		total += n
+
+		if err != nil || n == 0 {
+			return total, err
+		}
+	}
+}
+
+func startCleanupJob(urlService service.URLService, trashRetention time.Duration, logger *slog.Logger) {
 	ticker := time.NewTicker(24 * time.Hour)
 	defer ticker.Stop()
 
 	for range ticker.C {
 		logger.Info("Running cleanup job for expired urls")
 
-		query := "DELETE FROM urls WHERE expires_at <= NOW()"
-
-		result, err := db.Exec(query)
+		rows, err := drain(func() (int, error) {
+			return urlService.DeleteExpiredURLs(context.Background(), cleanupBatchSize)
+		})
 		if err != nil {
 			logger.Error("failed to clean expired urls", "error", err)
 			continue
 		}
 
-		rows, _ := result.RowsAffected()
+		deletedBefore := time.Now().UTC().Add(-trashRetention)
+
+		purged, err := drain(func() (int, error) {
+			return urlService.PurgeTrash(context.Background(), deletedBefore, cleanupBatchSize)
+		})
+		if err != nil {
+			logger.Error("failed to purge trashed urls", "error", err)
+			continue
+		}
 
-		logger.Info("Cleanup job completed", "deleted rows", rows)
+		logger.Info("Cleanup job completed", "deleted rows", rows, "purged rows", purged)
 	}
 }
@@ -1,9 +1,11 @@
 package config
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -18,6 +20,7 @@ type Config struct {
 
 type ServerConfig struct {
 	Port         string
+	GRPCPort     string
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
 	IdleTimeout  time.Duration
@@ -42,10 +45,89 @@ type RedisConfig struct {
 }
 
 type AppConfig struct {
-	BaseURL     string
-	ShortLength int
-	CacheTTL    time.Duration
-	Environment string
+	BaseURL              string
+	ShortLength          int
+	CacheTTL             time.Duration
+	Environment          string
+	ReuseExisting        bool
+	AdminAPIKey          string
+	TrashRetention       time.Duration
+	DomainReloadInterval time.Duration
+	IdempotencyWindow    time.Duration
+	GraphQLMaxComplexity int
+	Normalize            NormalizeConfig
+	Policy               PolicyConfig
+	Blocklist            BlocklistConfig
+	Interstitial         InterstitialConfig
+	Pages                PagesConfig
+	HealthCheck          HealthCheckConfig
+	Webhook              WebhookConfig
+}
+
+type PolicyConfig struct {
+	AllowedSchemes  []string
+	AllowedDomains  []string
+	DeniedDomains   []string
+	BlockPrivateIPs bool
+}
+
+// BlocklistConfig sources are "format:path" pairs, where format is one of
+// domains, hosts or hashprefix.
+type BlocklistConfig struct {
+	Sources        []string
+	ReloadInterval time.Duration
+}
+
+// InterstitialConfig lists the creator trust levels whose links show a
+// "you are leaving" page by default.
+type InterstitialConfig struct {
+	TrustLevels []string
+	Countdown   time.Duration
+}
+
+// PagesConfig controls what browsers see for links that cannot be served.
+// Dir holds custom templates; the URLs are the root, catch-all and fallback
+// redirects of the default domain.
+type PagesConfig struct {
+	Dir         string
+	NotFoundURL string
+	ExpiredURL  string
+	DisabledURL string
+	RootURL     string
+	CatchAllURL string
+}
+
+// HealthCheckConfig controls the background checker that probes link
+// destinations. It sends outbound requests to arbitrary hosts, so it is
+// off unless APP_HEALTH_CHECK_ENABLED turns it on.
+type HealthCheckConfig struct {
+	Enabled     bool
+	Interval    time.Duration
+	Timeout     time.Duration
+	Concurrency int
+	HostDelay   time.Duration
+	BatchSize   int
+	MaxAge      time.Duration
+}
+
+// WebhookConfig controls outgoing webhook delivery. Failed deliveries are
+// retried after Backoff, doubling up to MaxBackoff, until MaxAttempts is
+// reached. ClickThresholds are the click counts that fire
+// link.click_threshold.
+type WebhookConfig struct {
+	PollInterval    time.Duration
+	Timeout         time.Duration
+	Concurrency     int
+	BatchSize       int
+	MaxAttempts     int
+	Backoff         time.Duration
+	MaxBackoff      time.Duration
+	ClickThresholds []int64
+}
+
+type NormalizeConfig struct {
+	SortQuery   bool
+	StripParams []string
 }
 
 func LoadConfig() (*Config, error) {
@@ -56,6 +138,7 @@ func LoadConfig() (*Config, error) {
 	config := &Config{
 		Server: ServerConfig{
 			Port:         getEnv("SERVER_PORT", "8080"),
+			GRPCPort:     getEnv("SERVER_GRPC_PORT", "9090"),
 			ReadTimeout:  getDurationEnv("SERVER_READ_TIMEOUT", 15*time.Second),
 			WriteTimeout: getDurationEnv("SERVER_WRITE_TIMEOUT", 15*time.Second),
 			IdleTimeout:  getDurationEnv("SERVER_IDLE_TIMEOUT", 60*time.Second),
@@ -77,17 +160,99 @@ func LoadConfig() (*Config, error) {
 			DB:       getIntEnv("REDIS_DB", 0),
 		},
 		App: AppConfig{
-			BaseURL:     getEnv("APP_BASE_URL", "http://localhost:8080"),
-			ShortLength: getIntEnv("APP_SHORT_LENGTH", 6),
-			CacheTTL:    getDurationEnv("APP_CACHE_TTL", 24*time.Hour),
-			Environment: getEnv("APP_ENV", "development"),
+			BaseURL:              getEnv("APP_BASE_URL", "http://localhost:8080"),
+			ShortLength:          getIntEnv("APP_SHORT_LENGTH", 6),
+			CacheTTL:             getDurationEnv("APP_CACHE_TTL", 24*time.Hour),
+			Environment:          getEnv("APP_ENV", "development"),
+			ReuseExisting:        getBoolEnv("APP_REUSE_EXISTING", false),
+			AdminAPIKey:          getEnv("APP_ADMIN_API_KEY", ""),
+			TrashRetention:       getDurationEnv("APP_TRASH_RETENTION", 30*24*time.Hour),
+			DomainReloadInterval: getDurationEnv("APP_DOMAIN_RELOAD_INTERVAL", time.Minute),
+			IdempotencyWindow:    getDurationEnv("APP_IDEMPOTENCY_WINDOW", 24*time.Hour),
+			GraphQLMaxComplexity: getIntEnv("APP_GRAPHQL_MAX_COMPLEXITY", 1000),
+			Normalize: NormalizeConfig{
+				SortQuery:   getBoolEnv("APP_NORMALIZE_SORT_QUERY", true),
+				StripParams: getSliceEnv("APP_NORMALIZE_STRIP_PARAMS", nil),
+			},
+			Policy: PolicyConfig{
+				AllowedSchemes:  getSliceEnv("APP_ALLOWED_SCHEMES", []string{"http", "https"}),
+				AllowedDomains:  getSliceEnv("APP_ALLOWED_DOMAINS", nil),
+				DeniedDomains:   getSliceEnv("APP_DENIED_DOMAINS", nil),
+				BlockPrivateIPs: getBoolEnv("APP_BLOCK_PRIVATE_IPS", true),
+			},
+			Blocklist: BlocklistConfig{
+				Sources:        getSliceEnv("APP_BLOCKLIST_SOURCES", nil),
+				ReloadInterval: getDurationEnv("APP_BLOCKLIST_RELOAD_INTERVAL", 15*time.Minute),
+			},
+			Interstitial: InterstitialConfig{
+				TrustLevels: getSliceEnv("APP_INTERSTITIAL_TRUST_LEVELS", nil),
+				Countdown:   getDurationEnv("APP_INTERSTITIAL_COUNTDOWN", 5*time.Second),
+			},
+			Pages: PagesConfig{
+				Dir:         getEnv("APP_PAGES_DIR", ""),
+				NotFoundURL: getEnv("APP_NOT_FOUND_URL", ""),
+				ExpiredURL:  getEnv("APP_EXPIRED_URL", ""),
+				DisabledURL: getEnv("APP_DISABLED_URL", ""),
+				RootURL:     getEnv("APP_ROOT_URL", ""),
+				CatchAllURL: getEnv("APP_CATCH_ALL_URL", ""),
+			},
+			HealthCheck: HealthCheckConfig{
+				Enabled:     getBoolEnv("APP_HEALTH_CHECK_ENABLED", false),
+				Interval:    getDurationEnv("APP_HEALTH_CHECK_INTERVAL", time.Hour),
+				Timeout:     getDurationEnv("APP_HEALTH_CHECK_TIMEOUT", 10*time.Second),
+				Concurrency: getIntEnv("APP_HEALTH_CHECK_CONCURRENCY", 8),
+				HostDelay:   getDurationEnv("APP_HEALTH_CHECK_HOST_DELAY", 2*time.Second),
+				BatchSize:   getIntEnv("APP_HEALTH_CHECK_BATCH_SIZE", 500),
+				MaxAge:      getDurationEnv("APP_HEALTH_CHECK_MAX_AGE", 24*time.Hour),
+			},
+			Webhook: WebhookConfig{
+				PollInterval:    getDurationEnv("APP_WEBHOOK_POLL_INTERVAL", 5*time.Second),
+				Timeout:         getDurationEnv("APP_WEBHOOK_TIMEOUT", 10*time.Second),
+				Concurrency:     getIntEnv("APP_WEBHOOK_CONCURRENCY", 8),
+				BatchSize:       getIntEnv("APP_WEBHOOK_BATCH_SIZE", 100),
+				MaxAttempts:     getIntEnv("APP_WEBHOOK_MAX_ATTEMPTS", 8),
+				Backoff:         getDurationEnv("APP_WEBHOOK_BACKOFF", 30*time.Second),
+				MaxBackoff:      getDurationEnv("APP_WEBHOOK_MAX_BACKOFF", 6*time.Hour),
+				ClickThresholds: getInt64SliceEnv("APP_WEBHOOK_CLICK_THRESHOLDS", []int64{100, 1000, 10000}),
+			},
 		},
 	}
 
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
 	return config, nil
 
 }
 
+// validate rejects settings the server cannot run with. Intervals drive
+// tickers, which panic on non-positive durations, and a zero timeout would
+// let a slow destination hold a worker forever. A zero idempotency window
+// would store keys that expire at once and never replay a response.
+func (c *Config) validate() error {
+	durations := []struct {
+		key   string
+		value time.Duration
+	}{
+		{"APP_DOMAIN_RELOAD_INTERVAL", c.App.DomainReloadInterval},
+		{"APP_IDEMPOTENCY_WINDOW", c.App.IdempotencyWindow},
+		{"APP_BLOCKLIST_RELOAD_INTERVAL", c.App.Blocklist.ReloadInterval},
+		{"APP_HEALTH_CHECK_INTERVAL", c.App.HealthCheck.Interval},
+		{"APP_HEALTH_CHECK_TIMEOUT", c.App.HealthCheck.Timeout},
+		{"APP_WEBHOOK_POLL_INTERVAL", c.App.Webhook.PollInterval},
+		{"APP_WEBHOOK_TIMEOUT", c.App.Webhook.Timeout},
+	}
+
+	for _, d := range durations {
+		if d.value <= 0 {
+			return fmt.Errorf("%s must be a positive duration, got %s", d.key, d.value)
+		}
+	}
+
+	return nil
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -115,3 +280,43 @@ func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
 
 	return defaultValue
 }
+
+func getBoolEnv(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolVal, err := strconv.ParseBool(value); err == nil {
+			return boolVal
+		}
+	}
+
+	return defaultValue
+}
+
+func getSliceEnv(key string, defaultValue []string) []string {
+	if value := os.Getenv(key); value != "" {
+		var values []string
+		for item := range strings.SplitSeq(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, item)
+			}
+		}
+
+		return values
+	}
+
+	return defaultValue
+}
+
+func getInt64SliceEnv(key string, defaultValue []int64) []int64 {
+	if value := os.Getenv(key); value != "" {
+		var values []int64
+		for _, item := range getSliceEnv(key, nil) {
+			if intVal, err := strconv.ParseInt(item, 10, 64); err == nil {
+				values = append(values, intVal)
+			}
+		}
+
+		return values
+	}
+
+	return defaultValue
+}
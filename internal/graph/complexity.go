@@ -0,0 +1,98 @@
+package graph
+
+import (
+	"strconv"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// complexity estimates the cost of running an operation. Every field costs
+// one, and the selections of a connection field are multiplied by the page
+// size it asks for, so nesting connections grows the cost quickly.
+func complexity(doc *ast.Document, operationName string, variables map[string]any) int {
+	fragments := map[string]*ast.FragmentDefinition{}
+	var operation *ast.OperationDefinition
+
+	for _, definition := range doc.Definitions {
+		switch def := definition.(type) {
+		case *ast.FragmentDefinition:
+			fragments[def.Name.Value] = def
+		case *ast.OperationDefinition:
+			if operation == nil || (def.Name != nil && def.Name.Value == operationName) {
+				operation = def
+			}
+		}
+	}
+
+	if operation == nil {
+		return 0
+	}
+
+	return selectionCost(operation.SelectionSet, fragments, variables)
+}
+
+func selectionCost(set *ast.SelectionSet, fragments map[string]*ast.FragmentDefinition, variables map[string]any) int {
+	if set == nil {
+		return 0
+	}
+
+	cost := 0
+	for _, selection := range set.Selections {
+		switch sel := selection.(type) {
+		case *ast.Field:
+			children := selectionCost(sel.SelectionSet, fragments, variables)
+			if connectionFields[sel.Name.Value] {
+				children *= pageSizeArgument(sel, variables)
+			}
+
+			cost += 1 + children
+		case *ast.InlineFragment:
+			cost += selectionCost(sel.SelectionSet, fragments, variables)
+		case *ast.FragmentSpread:
+			if fragment, ok := fragments[sel.Name.Value]; ok {
+				cost += selectionCost(fragment.SelectionSet, fragments, variables)
+			}
+		}
+	}
+
+	return cost
+}
+
+// pageSizeArgument returns the "first" argument of a connection field,
+// falling back to the default page size. Values outside 1..maxPageSize are
+// rejected when the field resolves, but other fields may still run, so they
+// are costed as the largest page.
+func pageSizeArgument(field *ast.Field, variables map[string]any) int {
+	for _, arg := range field.Arguments {
+		if arg.Name.Value != "first" {
+			continue
+		}
+
+		switch value := arg.Value.(type) {
+		case *ast.IntValue:
+			if n, err := strconv.Atoi(value.Value); err == nil {
+				return clampPageSize(n)
+			}
+
+			return maxPageSize
+		case *ast.Variable:
+			if n, ok := variables[value.Name.Value].(float64); ok {
+				return clampPageSize(int(n))
+			}
+
+			if n, ok := variables[value.Name.Value].(int); ok {
+				return clampPageSize(n)
+			}
+		}
+	}
+
+	return defaultPageSize
+}
+
+func clampPageSize(n int) int {
+	if n < 1 || n > maxPageSize {
+		return maxPageSize
+	}
+
+	return n
+}
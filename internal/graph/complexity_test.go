@@ -0,0 +1,76 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+func TestComplexity(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]any
+		want      int
+	}{
+		{
+			name:  "plain fields",
+			query: `{ link(code: "x") { id shortCode } }`,
+			want:  3,
+		},
+		{
+			name:  "default page size",
+			query: `{ links { edges { node { id } } } }`,
+			want:  1 + 3*defaultPageSize,
+		},
+		{
+			name:  "requested page size",
+			query: `{ links(first: 5) { edges { node { id } } } }`,
+			want:  1 + 3*5,
+		},
+		{
+			name:  "nested connections multiply",
+			query: `{ links(first: 10) { edges { node { history(first: 10) { edges { node { version } } } } } } }`,
+			want:  1 + 10*(3+10*3),
+		},
+		{
+			name:  "negative page size costs the largest page",
+			query: `{ links(first: -1000000) { edges { node { id } } } }`,
+			want:  1 + 3*maxPageSize,
+		},
+		{
+			name:  "oversized page size costs the largest page",
+			query: `{ links(first: 99999999999999999999) { edges { node { id } } } }`,
+			want:  1 + 3*maxPageSize,
+		},
+		{
+			name:      "negative variable costs the largest page",
+			query:     `query ($n: Int) { links(first: $n) { edges { node { id } } } }`,
+			variables: map[string]any{"n": float64(-5)},
+			want:      1 + 3*maxPageSize,
+		},
+		{
+			name:  "negative sibling cannot offset the cost of another field",
+			query: `{ a: links(first: 100) { edges { node { id } } } z: link(code: "x") { history(first: -1000000) { edges { node { version } } } } }`,
+			want:  (1 + 3*100) + (1 + 1 + 3*maxPageSize),
+		},
+		{
+			name:  "fragments are counted",
+			query: `{ links(first: 2) { ...page } } fragment page on LinkConnection { edges { node { id shortCode } } }`,
+			want:  1 + 2*4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parser.Parse(parser.ParseParams{Source: tt.query})
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+
+			if got := complexity(doc, "", tt.variables); got != tt.want {
+				t.Errorf("complexity = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
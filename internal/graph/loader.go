@@ -0,0 +1,64 @@
+package graph
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// loader batches the lookups by URL ID made while resolving one level of a
+// query into a single fetch. The executor resolves every field on a level
+// before calling the thunks load returns, so all links in a page share one
+// query instead of issuing one each.
+type loader[V any] struct {
+	fetch func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]V, error)
+
+	mu      sync.Mutex
+	pending []uuid.UUID
+	queued  map[uuid.UUID]bool
+	results map[uuid.UUID]V
+	errs    map[uuid.UUID]error
+}
+
+func newLoader[V any](fetch func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]V, error)) *loader[V] {
+	return &loader[V]{
+		fetch:   fetch,
+		queued:  map[uuid.UUID]bool{},
+		results: map[uuid.UUID]V{},
+		errs:    map[uuid.UUID]error{},
+	}
+}
+
+// load queues id and returns a thunk yielding its value. The first thunk
+// called fetches everything queued so far.
+func (l *loader[V]) load(ctx context.Context, id uuid.UUID) func() (V, error) {
+	l.mu.Lock()
+	if !l.queued[id] {
+		l.queued[id] = true
+		l.pending = append(l.pending, id)
+	}
+	l.mu.Unlock()
+
+	return func() (V, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if len(l.pending) > 0 {
+			batch := l.pending
+			l.pending = nil
+
+			res, err := l.fetch(ctx, batch)
+			for _, id := range batch {
+				if err != nil {
+					l.errs[id] = err
+					continue
+				}
+
+				l.results[id] = res[id]
+			}
+		}
+
+		return l.results[id], l.errs[id]
+	}
+}
@@ -0,0 +1,200 @@
+package graph
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+	"github.com/ifaisalabid1/url-shortener/internal/model"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// connectionFields are the fields paginated with first/after; complexity
+// scales their selections by the requested page size.
+var connectionFields = map[string]bool{
+	"links":   true,
+	"history": true,
+}
+
+// field builds a field resolved by calling fn on the parent object.
+func field[T any](typ graphql.Output, fn func(T) any) *graphql.Field {
+	return &graphql.Field{
+		Type: typ,
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			return fn(p.Source.(T)), nil
+		},
+	}
+}
+
+var nonNullString = graphql.NewNonNull(graphql.String)
+
+var pageInfoType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "PageInfo",
+	Fields: graphql.Fields{
+		"hasNextPage": field(graphql.NewNonNull(graphql.Boolean), func(p *pageInfo) any { return p.HasNextPage }),
+		"endCursor":   field(graphql.String, func(p *pageInfo) any { return p.EndCursor }),
+	},
+})
+
+var openGraphType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "OpenGraph",
+	Fields: graphql.Fields{
+		"title":       field(graphql.String, func(o *model.OpenGraph) any { return o.Title }),
+		"description": field(graphql.String, func(o *model.OpenGraph) any { return o.Description }),
+		"image":       field(graphql.String, func(o *model.OpenGraph) any { return o.Image }),
+	},
+})
+
+var aliasType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "Alias",
+	Description: "An extra short code for a link, with its own click count.",
+	Fields: graphql.Fields{
+		"shortCode": field(nonNullString, func(a *model.AliasResponse) any { return a.ShortCode }),
+		"shortUrl":  field(nonNullString, func(a *model.AliasResponse) any { return a.ShortURL }),
+		"clicks":    field(graphql.NewNonNull(graphql.Int), func(a *model.AliasResponse) any { return a.Clicks }),
+		"createdAt": field(graphql.NewNonNull(graphql.DateTime), func(a *model.AliasResponse) any { return a.CreatedAt }),
+	},
+})
+
+var snapshotType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "LinkSnapshot",
+	Description: "The editable state of a link at one version.",
+	Fields: graphql.Fields{
+		"originalUrl":  field(nonNullString, func(s *model.URLSnapshot) any { return s.OriginalURL }),
+		"expiresAt":    field(graphql.DateTime, func(s *model.URLSnapshot) any { return s.ExpiresAt }),
+		"interstitial": field(graphql.Boolean, func(s *model.URLSnapshot) any { return s.Interstitial }),
+		"openGraph":    field(openGraphType, func(s *model.URLSnapshot) any { return s.OpenGraph }),
+		"folder":       field(graphql.String, func(s *model.URLSnapshot) any { return s.Folder }),
+		"tags":         field(graphql.NewList(nonNullString), func(s *model.URLSnapshot) any { return s.Tags }),
+	},
+})
+
+var historyEntryType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "HistoryEntry",
+	Fields: graphql.Fields{
+		"version":   field(graphql.NewNonNull(graphql.Int), func(e *model.HistoryEntry) any { return e.Version }),
+		"action":    field(nonNullString, func(e *model.HistoryEntry) any { return e.Action }),
+		"actor":     field(nonNullString, func(e *model.HistoryEntry) any { return e.Actor }),
+		"oldValue":  field(snapshotType, func(e *model.HistoryEntry) any { return e.OldValue }),
+		"newValue":  field(snapshotType, func(e *model.HistoryEntry) any { return e.NewValue }),
+		"createdAt": field(graphql.NewNonNull(graphql.DateTime), func(e *model.HistoryEntry) any { return e.CreatedAt }),
+	},
+})
+
+var tagStatsType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "TagStats",
+	Fields: graphql.Fields{
+		"tag":    field(nonNullString, func(t *model.TagStats) any { return t.Tag }),
+		"links":  field(graphql.NewNonNull(graphql.Int), func(t *model.TagStats) any { return t.Links }),
+		"clicks": field(graphql.NewNonNull(graphql.Int), func(t *model.TagStats) any { return t.Clicks }),
+	},
+})
+
+// connectionArgs are the arguments of every connection field.
+var connectionArgs = graphql.FieldConfigArgument{
+	"first": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultPageSize},
+	"after": &graphql.ArgumentConfig{Type: graphql.String},
+}
+
+// connectionType builds the Connection and Edge types for node.
+func connectionType(name string, node graphql.Output) *graphql.Object {
+	edge := graphql.NewObject(graphql.ObjectConfig{
+		Name: name + "Edge",
+		Fields: graphql.Fields{
+			"cursor": field(nonNullString, func(e *edge) any { return e.Cursor }),
+			"node":   field(graphql.NewNonNull(node), func(e *edge) any { return e.Node }),
+		},
+	})
+
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: name + "Connection",
+		Fields: graphql.Fields{
+			"edges":    field(graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(edge))), func(c *connection) any { return c.Edges }),
+			"pageInfo": field(graphql.NewNonNull(pageInfoType), func(c *connection) any { return c.PageInfo }),
+		},
+	})
+}
+
+type connection struct {
+	Edges    []*edge
+	PageInfo *pageInfo
+}
+
+type edge struct {
+	Cursor string
+	Node   any
+}
+
+type pageInfo struct {
+	HasNextPage bool
+	EndCursor   *string
+}
+
+// page reads the first/after arguments as a limit and an offset.
+func page(args map[string]any) (limit, offset int, err error) {
+	limit, _ = args["first"].(int)
+	if limit < 1 || limit > maxPageSize {
+		return 0, 0, fmt.Errorf("first must be between 1 and %d", maxPageSize)
+	}
+
+	if after, ok := args["after"].(string); ok {
+		position, err := decodeCursor(after)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		offset = position + 1
+	}
+
+	return limit, offset, nil
+}
+
+// newConnection wraps one page of nodes starting at offset. nodes may hold
+// one extra item, which only signals that another page exists.
+func newConnection[T any](nodes []T, limit, offset int) *connection {
+	conn := &connection{
+		Edges:    []*edge{},
+		PageInfo: &pageInfo{HasNextPage: len(nodes) > limit},
+	}
+
+	for i, node := range nodes[:min(len(nodes), limit)] {
+		cursor := encodeCursor(offset + i)
+		conn.Edges = append(conn.Edges, &edge{Cursor: cursor, Node: node})
+		conn.PageInfo.EndCursor = &cursor
+	}
+
+	return conn
+}
+
+// Cursors are opaque to clients but are positions in the result set.
+func encodeCursor(position int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("cursor:" + strconv.Itoa(position)))
+}
+
+func decodeCursor(cursor string) (int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, errors.New("invalid cursor")
+	}
+
+	position, err := strconv.Atoi(strings.TrimPrefix(string(raw), "cursor:"))
+	if err != nil || position < 0 || !strings.HasPrefix(string(raw), "cursor:") {
+		return 0, errors.New("invalid cursor")
+	}
+
+	return position, nil
+}
+
+// urlID parses the ID of a link, which the service layer returns as text.
+func urlID(url *model.URLResponse) uuid.UUID {
+	id, _ := uuid.Parse(url.ID)
+	return id
+}
@@ -0,0 +1,300 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/ifaisalabid1/url-shortener/internal/model"
+	"github.com/ifaisalabid1/url-shortener/internal/repository"
+	"github.com/ifaisalabid1/url-shortener/internal/service"
+)
+
+// GraphQLRequest is a GraphQL query sent over HTTP.
+type GraphQLRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitzero"`
+	Variables     map[string]any `json:"variables,omitzero"`
+}
+
+// GraphQLResponse is the result of executing a GraphQLRequest.
+type GraphQLResponse struct {
+	Data   any                        `json:"data,omitzero"`
+	Errors []gqlerrors.FormattedError `json:"errors,omitzero"`
+}
+
+// Server executes GraphQL queries against the URL service.
+type Server struct {
+	schema        graphql.Schema
+	urlService    service.URLService
+	domainService service.DomainService
+	maxComplexity int
+	logger        *slog.Logger
+}
+
+// loaders batch the per-link lookups of one request.
+type loaders struct {
+	aliases *loader[[]*model.AliasResponse]
+	history *loader[[]*model.HistoryEntry]
+}
+
+type loadersKey struct{}
+
+func NewServer(urlService service.URLService, domainService service.DomainService, maxComplexity int, logger *slog.Logger) (*Server, error) {
+	s := &Server{
+		urlService:    urlService,
+		domainService: domainService,
+		maxComplexity: maxComplexity,
+		logger:        logger,
+	}
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: s.queryType()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build graphql schema: %w", err)
+	}
+
+	s.schema = schema
+
+	return s, nil
+}
+
+// Execute parses, validates and runs req. Queries above the complexity limit
+// are rejected before anything is resolved.
+func (s *Server) Execute(ctx context.Context, req *GraphQLRequest) *GraphQLResponse {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"}),
+	})
+	if err != nil {
+		return &GraphQLResponse{Errors: []gqlerrors.FormattedError{gqlerrors.FormatError(err)}}
+	}
+
+	if result := graphql.ValidateDocument(&s.schema, doc, nil); !result.IsValid {
+		return &GraphQLResponse{Errors: result.Errors}
+	}
+
+	if cost := complexity(doc, req.OperationName, req.Variables); cost > s.maxComplexity {
+		return &GraphQLResponse{Errors: []gqlerrors.FormattedError{
+			gqlerrors.NewFormattedError(fmt.Sprintf("query complexity %d exceeds the limit of %d", cost, s.maxComplexity)),
+		}}
+	}
+
+	ctx = context.WithValue(ctx, loadersKey{}, &loaders{
+		aliases: newLoader(s.urlService.ListAliasesByURLIDs),
+		history: newLoader(s.urlService.ListHistoryByURLIDs),
+	})
+
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        s.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       ctx,
+	})
+
+	return &GraphQLResponse{Data: result.Data, Errors: result.Errors}
+}
+
+// Errors for fields requested without the admin API key, matching the REST
+// routes that need it.
+var (
+	errHistoryForbidden = errors.New("history requires the admin API key")
+	errListForbidden    = errors.New("listing links and tags requires the admin API key")
+)
+
+// trusted reports whether the caller holds the admin API key.
+func trusted(ctx context.Context) bool {
+	return service.ActorFromContext(ctx).Trust == model.TrustTrusted
+}
+
+// nonNil turns a nil slice into an empty one for non-null list fields.
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+
+	return items
+}
+
+func loadersFromContext(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// resolveError hides unexpected errors from clients, keeping only the
+// messages of errors callers can act on.
+func (s *Server) resolveError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrUnknownDomain),
+		errors.Is(err, service.ErrURLDisabled),
+		errors.Is(err, service.ErrURLBanned),
+		errors.Is(err, service.ErrURLExpired):
+		return err
+	default:
+		s.logger.Error("graphql resolver failed", "error", err)
+		return errors.New("internal error")
+	}
+}
+
+func (s *Server) linkType() *graphql.Object {
+	link := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Link",
+		Fields: graphql.Fields{
+			"id":           field(graphql.NewNonNull(graphql.ID), func(u *model.URLResponse) any { return u.ID }),
+			"shortCode":    field(nonNullString, func(u *model.URLResponse) any { return u.ShortCode }),
+			"domain":       field(graphql.String, func(u *model.URLResponse) any { return u.Domain }),
+			"shortUrl":     field(nonNullString, func(u *model.URLResponse) any { return u.ShortURL }),
+			"originalUrl":  field(nonNullString, func(u *model.URLResponse) any { return u.OriginalURL }),
+			"status":       field(nonNullString, func(u *model.URLResponse) any { return u.Status }),
+			"clicks":       field(graphql.NewNonNull(graphql.Int), func(u *model.URLResponse) any { return u.Clicks }),
+			"createdAt":    field(graphql.NewNonNull(graphql.DateTime), func(u *model.URLResponse) any { return u.CreatedAt }),
+			"expiresAt":    field(graphql.DateTime, func(u *model.URLResponse) any { return u.ExpiresAt }),
+			"interstitial": field(graphql.Boolean, func(u *model.URLResponse) any { return u.Interstitial }),
+			"openGraph":    field(openGraphType, func(u *model.URLResponse) any { return u.OpenGraph }),
+			"folder":       field(graphql.String, func(u *model.URLResponse) any { return u.Folder }),
+			"tags":         field(graphql.NewNonNull(graphql.NewList(nonNullString)), func(u *model.URLResponse) any { return nonNil(u.Tags) }),
+		},
+	})
+
+	link.AddFieldConfig("aliases", &graphql.Field{
+		Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(aliasType))),
+		Description: "The link's aliases; with clicks, the click breakdown by short code.",
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			thunk := loadersFromContext(p.Context).aliases.load(p.Context, urlID(p.Source.(*model.URLResponse)))
+
+			return func() (any, error) {
+				aliases, err := thunk()
+				if err != nil {
+					return nil, s.resolveError(err)
+				}
+
+				return nonNil(aliases), nil
+			}, nil
+		},
+	})
+
+	link.AddFieldConfig("history", &graphql.Field{
+		Type:        graphql.NewNonNull(connectionType("History", historyEntryType)),
+		Description: "The link's edit history. Requires the admin API key.",
+		Args:        connectionArgs,
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			if !trusted(p.Context) {
+				return nil, errHistoryForbidden
+			}
+
+			limit, offset, err := page(p.Args)
+			if err != nil {
+				return nil, err
+			}
+
+			thunk := loadersFromContext(p.Context).history.load(p.Context, urlID(p.Source.(*model.URLResponse)))
+
+			return func() (any, error) {
+				entries, err := thunk()
+				if err != nil {
+					return nil, s.resolveError(err)
+				}
+
+				entries = entries[min(offset, len(entries)):]
+				entries = entries[:min(limit+1, len(entries))]
+
+				return newConnection(entries, limit, offset), nil
+			}, nil
+		},
+	})
+
+	return link
+}
+
+func (s *Server) queryType() *graphql.Object {
+	link := s.linkType()
+
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"link": &graphql.Field{
+				Type: link,
+				Args: graphql.FieldConfigArgument{
+					"code":   &graphql.ArgumentConfig{Type: nonNullString},
+					"domain": &graphql.ArgumentConfig{Type: graphql.String, Description: "Branded short domain; omit for the default domain."},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					ctx := p.Context
+					if host, _ := p.Args["domain"].(string); host != "" {
+						host = service.NormalizeHost(host)
+						if !s.domainService.Has(host) {
+							return nil, service.ErrUnknownDomain
+						}
+
+						ctx = service.WithDomain(ctx, host)
+					}
+
+					url, err := s.urlService.GetURLPreview(ctx, p.Args["code"].(string))
+					if errors.Is(err, repository.ErrURLNotFound) {
+						return nil, nil
+					}
+
+					if err != nil {
+						return nil, s.resolveError(err)
+					}
+
+					return url, nil
+				},
+			},
+			"links": &graphql.Field{
+				Type:        graphql.NewNonNull(connectionType("Link", link)),
+				Description: "All links, newest first. Requires the admin API key.",
+				Args: graphql.FieldConfigArgument{
+					"first":  connectionArgs["first"],
+					"after":  connectionArgs["after"],
+					"tag":    &graphql.ArgumentConfig{Type: graphql.String},
+					"folder": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					if !trusted(p.Context) {
+						return nil, errListForbidden
+					}
+
+					limit, offset, err := page(p.Args)
+					if err != nil {
+						return nil, err
+					}
+
+					tag, _ := p.Args["tag"].(string)
+					folder, _ := p.Args["folder"].(string)
+
+					urls, err := s.urlService.ListURLs(p.Context, model.URLFilter{
+						Tag:    tag,
+						Folder: folder,
+						Limit:  limit + 1,
+						Offset: offset,
+					})
+					if err != nil {
+						return nil, s.resolveError(err)
+					}
+
+					return newConnection(urls, limit, offset), nil
+				},
+			},
+			"tags": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(tagStatsType))),
+				Description: "Tags with their link and click totals. Requires the admin API key.",
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					if !trusted(p.Context) {
+						return nil, errListForbidden
+					}
+
+					stats, err := s.urlService.ListTagStats(p.Context)
+					if err != nil {
+						return nil, s.resolveError(err)
+					}
+
+					return stats, nil
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ifaisalabid1/url-shortener/internal/model"
+	"github.com/ifaisalabid1/url-shortener/internal/repository"
+	"github.com/ifaisalabid1/url-shortener/internal/service"
+)
+
+type DomainHandler struct {
+	responder
+	domainService service.DomainService
+}
+
+func NewDomainHandler(domainService service.DomainService, logger *slog.Logger) *DomainHandler {
+	return &DomainHandler{
+		responder:     responder{logger: logger},
+		domainService: domainService,
+	}
+}
+
+func (h *DomainHandler) ListDomains(w http.ResponseWriter, r *http.Request) {
+	domains, err := h.domainService.List(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list short domains", "error", err)
+		h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, domains)
+}
+
+func (h *DomainHandler) RegisterDomain(w http.ResponseWriter, r *http.Request) {
+	var req model.CreateShortDomainRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.respondWithValidationError(w, r, err)
+		return
+	}
+
+	domain, err := h.domainService.Register(r.Context(), &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrShortDomainExists):
+			h.respondWithError(w, r, http.StatusConflict, "short domain already exists")
+		default:
+			h.logger.Error("failed to register short domain", "error", err)
+			h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		}
+
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusCreated, domain)
+}
+
+func (h *DomainHandler) UpdateDomain(w http.ResponseWriter, r *http.Request) {
+	var req model.DomainFallbacks
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.respondWithValidationError(w, r, err)
+		return
+	}
+
+	domain, err := h.domainService.UpdateFallbacks(r.Context(), chi.URLParam(r, "host"), &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrShortDomainNotFound):
+			h.respondWithError(w, r, http.StatusNotFound, "short domain not found")
+		default:
+			h.logger.Error("failed to update short domain", "error", err)
+			h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		}
+
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, domain)
+}
+
+func (h *DomainHandler) RemoveDomain(w http.ResponseWriter, r *http.Request) {
+	if err := h.domainService.Remove(r.Context(), chi.URLParam(r, "host")); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrShortDomainNotFound):
+			h.respondWithError(w, r, http.StatusNotFound, "short domain not found")
+		case errors.Is(err, repository.ErrShortDomainInUse):
+			h.respondWithError(w, r, http.StatusConflict, err.Error())
+		default:
+			h.logger.Error("failed to remove short domain", "error", err)
+			h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		}
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
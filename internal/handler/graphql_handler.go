@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/ifaisalabid1/url-shortener/internal/graph"
+)
+
+type GraphQLHandler struct {
+	responder
+	server *graph.Server
+}
+
+func NewGraphQLHandler(server *graph.Server, logger *slog.Logger) *GraphQLHandler {
+	return &GraphQLHandler{
+		responder: responder{logger: logger},
+		server:    server,
+	}
+}
+
+// Query executes a GraphQL query. As is conventional for GraphQL, errors in
+// the query itself are reported in the body of a 200 response.
+func (h *GraphQLHandler) Query(w http.ResponseWriter, r *http.Request) {
+	var req graph.GraphQLRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if strings.TrimSpace(req.Query) == "" {
+		h.respondWithError(w, r, http.StatusBadRequest, "query is required")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, h.server.Execute(r.Context(), &req))
+}
@@ -0,0 +1,355 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/ifaisalabid1/url-shortener/internal/model"
+	"github.com/ifaisalabid1/url-shortener/internal/service"
+)
+
+// isAdmin reports whether the request carries the admin API key as a bearer
+// token. An empty key disables admin access entirely.
+func isAdmin(r *http.Request, apiKey string) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || apiKey == "" {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(token), []byte(apiKey)) == 1
+}
+
+func requireAdmin(apiKey string, logger *slog.Logger) func(http.Handler) http.Handler {
+	res := &responder{logger: logger}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !isAdmin(r, apiKey) {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				res.respondWithError(w, r, http.StatusUnauthorized, "unauthorized")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// recoverer turns a panic in a handler into a 500 problem response and logs
+// it with its stack trace.
+func recoverer(logger *slog.Logger) func(http.Handler) http.Handler {
+	res := &responder{logger: logger}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				rvr := recover()
+				if rvr == nil {
+					return
+				}
+
+				// ErrAbortHandler is how a handler aborts its response on
+				// purpose, so it is passed on to the server.
+				if rvr == http.ErrAbortHandler {
+					panic(rvr)
+				}
+
+				logger.Error("handler panicked", "panic", rvr, "stack", string(debug.Stack()))
+
+				if r.Header.Get("Connection") != "Upgrade" {
+					res.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+				}
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// timeout cancels a request's context after d and answers with a 504 if the
+// handler gave up without responding. Live stats streams are left alone, as
+// they stay open until the client leaves.
+func timeout(d time.Duration, logger *slog.Logger) func(http.Handler) http.Handler {
+	res := &responder{logger: logger}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isLiveStream(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(r.Context(), d)
+			defer cancel()
+
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) && ww.Status() == 0 {
+				res.respondWithError(w, r, http.StatusGatewayTimeout, "request timed out")
+			}
+		})
+	}
+}
+
+// methodNotAllowed answers requests whose path matches a route but whose
+// method does not, listing the methods routes accepts for the path.
+func methodNotAllowed(routes chi.Routes, logger *slog.Logger) http.HandlerFunc {
+	res := &responder{logger: logger}
+
+	methods := []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		for _, method := range methods {
+			if routes.Match(chi.NewRouteContext(), method, r.URL.Path) {
+				w.Header().Add("Allow", method)
+			}
+		}
+
+		res.respondWithError(w, r, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func isLiveStream(r *http.Request) bool {
+	rest, ok := strings.CutPrefix(r.URL.Path, "/api/v1/stats/")
+	return ok && r.Method == http.MethodGet && strings.HasSuffix(rest, "/live")
+}
+
+// identify attaches the actor making the request to its context. Callers
+// holding the admin API key are trusted; everyone else is anonymous.
+func identify(apiKey string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			actor := &model.Actor{
+				Name:  model.TrustAnonymous,
+				IP:    clientIP(r),
+				Trust: model.TrustAnonymous,
+			}
+
+			if isAdmin(r, apiKey) {
+				actor.Name = "admin"
+				actor.Trust = model.TrustTrusted
+			}
+
+			next.ServeHTTP(w, r.WithContext(service.WithActor(r.Context(), actor)))
+		})
+	}
+}
+
+// isMutating reports whether the request method changes state.
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// audit records every authenticated mutating request, along with rejected
+// attempts against the admin API, once the response has been written.
+func audit(auditService service.AuditService, logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			actor := service.ActorFromContext(r.Context())
+			adminPath := strings.HasPrefix(r.URL.Path, "/api/v1/admin")
+
+			if !isMutating(r.Method) || (actor.Trust != model.TrustTrusted && !adminPath) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			start := time.Now()
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+			defer func() {
+				status := ww.Status()
+				if status == 0 {
+					status = http.StatusOK
+				}
+
+				entry := &model.AuditEntry{
+					RequestID:  middleware.GetReqID(r.Context()),
+					Actor:      actor.Name,
+					IP:         actor.IP,
+					Method:     r.Method,
+					Path:       r.URL.Path,
+					Status:     status,
+					Outcome:    auditOutcome(status),
+					DurationMS: time.Since(start).Milliseconds(),
+					CreatedAt:  start.UTC(),
+				}
+
+				if rctx := chi.RouteContext(r.Context()); rctx != nil {
+					entry.Route = rctx.RoutePattern()
+				}
+
+				if err := auditService.Record(context.WithoutCancel(r.Context()), entry); err != nil {
+					logger.Error("failed to record audit entry", "request_id", entry.RequestID, "error", err)
+				}
+			}()
+
+			next.ServeHTTP(ww, r)
+		})
+	}
+}
+
+func auditOutcome(status int) string {
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return model.AuditDenied
+	case status >= http.StatusBadRequest:
+		return model.AuditFailure
+	default:
+		return model.AuditSuccess
+	}
+}
+
+// hostDomain serves links on a registered branded domain when the request's
+// Host matches it. Any other host falls back to the default domain.
+func hostDomain(domainService service.DomainService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if host := service.NormalizeHost(r.Host); domainService.Has(host) {
+				r = r.WithContext(service.WithDomain(r.Context(), host))
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// queryDomain selects the short domain an API request addresses from its
+// domain query parameter, rejecting hosts that are not registered.
+func queryDomain(domainService service.DomainService, logger *slog.Logger) func(http.Handler) http.Handler {
+	res := &responder{logger: logger}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			host := r.URL.Query().Get("domain")
+			if host == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			host = service.NormalizeHost(host)
+			if !domainService.Has(host) {
+				res.respondWithError(w, r, http.StatusBadRequest, service.ErrUnknownDomain.Error())
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(service.WithDomain(r.Context(), host)))
+		})
+	}
+}
+
+// maxIdempotencyKeyLength caps the Idempotency-Key header so keys cannot be
+// used to store arbitrarily large values in Redis.
+const maxIdempotencyKeyLength = 255
+
+// idempotent replays the stored response when a request repeats an
+// Idempotency-Key, instead of running it again. Keys are scoped to the
+// actor, and server errors release the key so the request can be retried.
+func idempotent(idempotencyService service.IdempotencyService, logger *slog.Logger) func(http.Handler) http.Handler {
+	res := &responder{logger: logger}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get("Idempotency-Key")
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if len(key) > maxIdempotencyKeyLength {
+				res.respondWithError(w, r, http.StatusBadRequest, fmt.Sprintf("idempotency key must be at most %d characters", maxIdempotencyKeyLength))
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				res.respondWithError(w, r, http.StatusBadRequest, "invalid request body")
+				return
+			}
+
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			key = service.ActorFromContext(r.Context()).Scope() + ":" + key
+			fingerprint := requestFingerprint(r, body)
+
+			stored, err := idempotencyService.Begin(r.Context(), key, fingerprint)
+			switch {
+			case errors.Is(err, service.ErrIdempotencyKeyReused):
+				res.respondWithError(w, r, http.StatusUnprocessableEntity, err.Error())
+				return
+			case errors.Is(err, service.ErrIdempotencyKeyInFlight):
+				res.respondWithError(w, r, http.StatusConflict, err.Error())
+				return
+			case err != nil:
+				logger.Error("failed to check idempotency key", "error", err)
+				res.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+				return
+			case stored != nil:
+				w.Header().Set("Content-Type", stored.ContentType)
+				w.Header().Set("Idempotent-Replayed", "true")
+				w.WriteHeader(stored.Status)
+				w.Write(stored.Body)
+				return
+			}
+
+			var buf bytes.Buffer
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			ww.Tee(&buf)
+
+			next.ServeHTTP(ww, r)
+
+			ctx := context.WithoutCancel(r.Context())
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			if status >= http.StatusInternalServerError {
+				if err := idempotencyService.Release(ctx, key); err != nil {
+					logger.Error("failed to release idempotency key", "error", err)
+				}
+
+				return
+			}
+
+			err = idempotencyService.Complete(ctx, key, &model.IdempotentResponse{
+				Fingerprint: fingerprint,
+				Status:      status,
+				ContentType: ww.Header().Get("Content-Type"),
+				Body:        buf.Bytes(),
+			})
+			if err != nil {
+				logger.Error("failed to store idempotent response", "error", err)
+			}
+		})
+	}
+}
+
+// requestFingerprint identifies what a request asked for, so a reused
+// idempotency key can be told apart from a genuine retry.
+func requestFingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s %s?%s\n", r.Method, r.URL.Path, r.URL.RawQuery)
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
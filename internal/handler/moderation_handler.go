@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/ifaisalabid1/url-shortener/internal/model"
+	"github.com/ifaisalabid1/url-shortener/internal/repository"
+	"github.com/ifaisalabid1/url-shortener/internal/service"
+)
+
+type ModerationHandler struct {
+	responder
+	moderationService service.ModerationService
+}
+
+func NewModerationHandler(moderationService service.ModerationService, logger *slog.Logger) *ModerationHandler {
+	return &ModerationHandler{
+		responder:         responder{logger: logger},
+		moderationService: moderationService,
+	}
+}
+
+func (h *ModerationHandler) ReportURL(w http.ResponseWriter, r *http.Request) {
+	shortCode := chi.URLParam(r, "code")
+
+	var req model.CreateReportRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.respondWithValidationError(w, r, err)
+		return
+	}
+
+	_, err := h.moderationService.ReportURL(r.Context(), shortCode, clientIP(r), &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrURLNotFound):
+			h.respondWithError(w, r, http.StatusNotFound, "url not found")
+		default:
+			h.logger.Error("failed to report url", "error", err)
+			h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		}
+
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusAccepted, SuccessResponse{Message: "report received"})
+}
+
+func (h *ModerationHandler) ListReports(w http.ResponseWriter, r *http.Request) {
+	reports, err := h.moderationService.ListReports(r.Context(), r.URL.Query().Get("status"))
+	if err != nil {
+		h.logger.Error("failed to list reports", "error", err)
+		h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, reports)
+}
+
+func (h *ModerationHandler) ResolveReport(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, "invalid report id")
+		return
+	}
+
+	var req model.ResolveReportRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.respondWithValidationError(w, r, err)
+		return
+	}
+
+	report, err := h.moderationService.ResolveReport(r.Context(), id, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrReportNotFound):
+			h.respondWithError(w, r, http.StatusNotFound, "report not found")
+		default:
+			h.logger.Error("failed to resolve report", "error", err)
+			h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		}
+
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, report)
+}
+
+func (h *ModerationHandler) SetURLStatus(w http.ResponseWriter, r *http.Request) {
+	shortCode := chi.URLParam(r, "code")
+
+	var req model.SetURLStatusRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.respondWithValidationError(w, r, err)
+		return
+	}
+
+	res, err := h.moderationService.SetURLStatus(r.Context(), shortCode, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrURLNotFound):
+			h.respondWithError(w, r, http.StatusNotFound, "url not found")
+		default:
+			h.logger.Error("failed to set url status", "error", err)
+			h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		}
+
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, res)
+}
+
+func (h *ModerationHandler) ListBannedDomains(w http.ResponseWriter, r *http.Request) {
+	domains, err := h.moderationService.ListBannedDomains(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list banned domains", "error", err)
+		h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, domains)
+}
+
+func (h *ModerationHandler) BanDomain(w http.ResponseWriter, r *http.Request) {
+	var req model.BanDomainRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.respondWithValidationError(w, r, err)
+		return
+	}
+
+	res, err := h.moderationService.BanDomain(r.Context(), &req)
+	if err != nil {
+		h.logger.Error("failed to ban domain", "error", err)
+		h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusCreated, res)
+}
+
+func (h *ModerationHandler) UnbanDomain(w http.ResponseWriter, r *http.Request) {
+	if err := h.moderationService.UnbanDomain(r.Context(), chi.URLParam(r, "domain")); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrDomainNotFound):
+			h.respondWithError(w, r, http.StatusNotFound, "domain not found")
+		default:
+			h.logger.Error("failed to unban domain", "error", err)
+			h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		}
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// clientIP returns the caller's address as set by the RealIP middleware.
+func clientIP(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+
+	return r.RemoteAddr
+}
@@ -0,0 +1,387 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ifaisalabid1/url-shortener/internal/graph"
+	"github.com/ifaisalabid1/url-shortener/internal/model"
+)
+
+// apiOperation documents one route registered in Routes. Request and response
+// schemas are derived from the zero values of Body and Response.
+type apiOperation struct {
+	Method      string
+	Path        string
+	Summary     string
+	Tag         string
+	Admin       bool
+	Query       []apiParam
+	Headers     []apiParam
+	Body        any
+	Status      int
+	Response    any
+	ContentType string
+}
+
+type apiParam struct {
+	Name        string
+	Description string
+}
+
+var paginationParams = []apiParam{
+	{"limit", "Maximum number of results, between 1 and 500 (default 50)."},
+	{"offset", "Number of results to skip (default 0)."},
+}
+
+var domainParam = apiParam{"domain", "Branded short domain the code belongs to. Omit for the default domain."}
+
+// apiOperations must list every route in Routes; openapi_test.go enforces it.
+var apiOperations = []apiOperation{
+	{Method: http.MethodGet, Path: "/health", Summary: "Health check", Tag: "system", Status: http.StatusOK, Response: SuccessResponse{}},
+	{Method: http.MethodGet, Path: "/api/openapi.json", Summary: "This OpenAPI document", Tag: "system", Status: http.StatusOK, ContentType: "application/json"},
+	{Method: http.MethodPost, Path: "/api/graphql", Summary: "Run a GraphQL query over links, tags and history", Tag: "graphql", Body: graph.GraphQLRequest{}, Status: http.StatusOK, Response: graph.GraphQLResponse{}},
+
+	{Method: http.MethodPost, Path: "/api/v1/shorten", Summary: "Create a short link; an identical existing link of the caller may be returned with 200 instead", Tag: "links", Headers: []apiParam{{"Idempotency-Key", "Replays the original response when a request is retried with the same key and body."}}, Body: model.CreateURLRequest{}, Status: http.StatusCreated, Response: model.URLResponse{}},
+	{Method: http.MethodGet, Path: "/api/v1/stats/{code}", Summary: "Get link statistics", Tag: "links", Status: http.StatusOK, Response: model.URLStats{}},
+	{Method: http.MethodGet, Path: "/api/v1/stats/{code}/live", Summary: "Stream a link's clicks as server-sent events", Tag: "links", Headers: []apiParam{{"Last-Event-ID", "Click count of the last event received; recent clicks after it are replayed first."}}, Status: http.StatusOK, ContentType: "text/event-stream"},
+	{Method: http.MethodGet, Path: "/api/v1/tags", Summary: "List tags with link and click totals", Tag: "links", Admin: true, Status: http.StatusOK, Response: []model.TagStats{}},
+	{Method: http.MethodGet, Path: "/api/v1/urls", Summary: "List links", Tag: "links", Admin: true, Query: append([]apiParam{{"tag", "Only links with this tag."}, {"folder", "Only links in this folder."}}, paginationParams...), Status: http.StatusOK, Response: []model.URLResponse{}},
+	{Method: http.MethodGet, Path: "/api/v1/urls/broken", Summary: "List links whose destination is broken", Tag: "links", Admin: true, Query: paginationParams, Status: http.StatusOK, Response: []model.URLResponse{}},
+	{Method: http.MethodPatch, Path: "/api/v1/urls/{code}", Summary: "Update a link", Tag: "links", Admin: true, Body: model.UpdateURLRequest{}, Status: http.StatusOK, Response: model.URLResponse{}},
+	{Method: http.MethodDelete, Path: "/api/v1/urls/{code}", Summary: "Move a link to the trash", Tag: "links", Admin: true, Status: http.StatusNoContent},
+	{Method: http.MethodGet, Path: "/api/v1/urls/{code}/qr", Summary: "Render a QR code for a link", Tag: "links", Query: []apiParam{{"format", "png or svg (default png)."}, {"size", "Image size in pixels, 64 to 2048 (default 256)."}, {"ecc", "Error correction level L, M, Q or H (default M)."}, {"fg", "Foreground hex color (default 000000)."}, {"bg", "Background hex color (default ffffff)."}, {"quiet_zone", "Border in modules, 0 to 16 (default 4)."}}, Status: http.StatusOK, ContentType: "image/png"},
+	{Method: http.MethodGet, Path: "/api/v1/urls/{code}/aliases", Summary: "List a link's aliases", Tag: "aliases", Status: http.StatusOK, Response: []model.AliasResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/urls/{code}/aliases", Summary: "Add an alias to a link", Tag: "aliases", Admin: true, Body: model.CreateAliasRequest{}, Status: http.StatusCreated, Response: model.AliasResponse{}},
+	{Method: http.MethodDelete, Path: "/api/v1/urls/{code}/aliases/{alias}", Summary: "Remove an alias", Tag: "aliases", Admin: true, Status: http.StatusNoContent},
+	{Method: http.MethodGet, Path: "/api/v1/urls/{id}/history", Summary: "List a link's edit history", Tag: "history", Admin: true, Status: http.StatusOK, Response: []model.HistoryEntry{}},
+	{Method: http.MethodPost, Path: "/api/v1/urls/{id}/history/{version}/revert", Summary: "Revert a link to a version", Tag: "history", Admin: true, Status: http.StatusOK, Response: model.URLResponse{}},
+
+	{Method: http.MethodGet, Path: "/api/v1/trash", Summary: "List trashed links", Tag: "trash", Admin: true, Query: paginationParams, Status: http.StatusOK, Response: []model.URLResponse{}},
+	{Method: http.MethodPost, Path: "/api/v1/trash/{code}/restore", Summary: "Restore a trashed link", Tag: "trash", Admin: true, Status: http.StatusOK, Response: model.URLResponse{}},
+	{Method: http.MethodDelete, Path: "/api/v1/trash/{code}", Summary: "Permanently delete a trashed link", Tag: "trash", Admin: true, Status: http.StatusNoContent},
+
+	{Method: http.MethodGet, Path: "/api/v1/admin/reports", Summary: "List abuse reports", Tag: "admin", Admin: true, Query: []apiParam{{"status", "Only reports with this status."}}, Status: http.StatusOK, Response: []model.Report{}},
+	{Method: http.MethodPost, Path: "/api/v1/admin/reports/{id}/resolve", Summary: "Resolve an abuse report", Tag: "admin", Admin: true, Body: model.ResolveReportRequest{}, Status: http.StatusOK, Response: model.Report{}},
+	{Method: http.MethodPut, Path: "/api/v1/admin/urls/{code}/status", Summary: "Set a link's moderation status", Tag: "admin", Admin: true, Body: model.SetURLStatusRequest{}, Status: http.StatusOK, Response: model.URLResponse{}},
+	{Method: http.MethodGet, Path: "/api/v1/admin/banned-domains", Summary: "List banned destination domains", Tag: "admin", Admin: true, Status: http.StatusOK, Response: []model.BannedDomain{}},
+	{Method: http.MethodPost, Path: "/api/v1/admin/banned-domains", Summary: "Ban a destination domain", Tag: "admin", Admin: true, Body: model.BanDomainRequest{}, Status: http.StatusCreated, Response: model.BanDomainResponse{}},
+	{Method: http.MethodDelete, Path: "/api/v1/admin/banned-domains/{domain}", Summary: "Unban a destination domain", Tag: "admin", Admin: true, Status: http.StatusNoContent},
+	{Method: http.MethodGet, Path: "/api/v1/admin/audit", Summary: "Query the audit log", Tag: "admin", Admin: true, Query: append([]apiParam{{"from", "RFC 3339 start of the range (default 24 hours before to)."}, {"to", "RFC 3339 end of the range (default now)."}}, paginationParams...), Status: http.StatusOK, Response: []model.AuditEntry{}},
+	{Method: http.MethodGet, Path: "/api/v1/admin/audit/export", Summary: "Export the audit log as NDJSON", Tag: "admin", Admin: true, Query: []apiParam{{"from", "RFC 3339 start of the range (default 24 hours before to)."}, {"to", "RFC 3339 end of the range (default now)."}}, Status: http.StatusOK, ContentType: "application/x-ndjson"},
+	{Method: http.MethodGet, Path: "/api/v1/admin/domains", Summary: "List branded short domains", Tag: "admin", Admin: true, Status: http.StatusOK, Response: []model.ShortDomain{}},
+	{Method: http.MethodPost, Path: "/api/v1/admin/domains", Summary: "Register a branded short domain", Tag: "admin", Admin: true, Body: model.CreateShortDomainRequest{}, Status: http.StatusCreated, Response: model.ShortDomain{}},
+	{Method: http.MethodPut, Path: "/api/v1/admin/domains/{host}", Summary: "Set a short domain's redirects", Tag: "admin", Admin: true, Body: model.DomainFallbacks{}, Status: http.StatusOK, Response: model.ShortDomain{}},
+	{Method: http.MethodDelete, Path: "/api/v1/admin/domains/{host}", Summary: "Remove a short domain without links", Tag: "admin", Admin: true, Status: http.StatusNoContent},
+	{Method: http.MethodGet, Path: "/api/v1/admin/webhooks", Summary: "List webhook subscriptions", Tag: "admin", Admin: true, Status: http.StatusOK, Response: []model.WebhookSubscription{}},
+	{Method: http.MethodPost, Path: "/api/v1/admin/webhooks", Summary: "Subscribe a URL to link events", Tag: "admin", Admin: true, Body: model.CreateWebhookRequest{}, Status: http.StatusCreated, Response: model.WebhookSubscription{}},
+	{Method: http.MethodDelete, Path: "/api/v1/admin/webhooks/{id}", Summary: "Delete a webhook subscription", Tag: "admin", Admin: true, Status: http.StatusNoContent},
+	{Method: http.MethodGet, Path: "/api/v1/admin/webhooks/deliveries", Summary: "List webhook deliveries", Tag: "admin", Admin: true, Query: append([]apiParam{{"status", "Only deliveries with this status: pending, delivered or dead."}}, paginationParams...), Status: http.StatusOK, Response: []model.WebhookDelivery{}},
+	{Method: http.MethodGet, Path: "/api/v1/admin/webhooks/deliveries/{id}/attempts", Summary: "List a delivery's attempts", Tag: "admin", Admin: true, Status: http.StatusOK, Response: []model.WebhookAttempt{}},
+	{Method: http.MethodPost, Path: "/api/v1/admin/webhooks/deliveries/{id}/retry", Summary: "Retry a dead webhook delivery", Tag: "admin", Admin: true, Status: http.StatusAccepted, Response: SuccessResponse{}},
+
+	{Method: http.MethodPost, Path: "/report/{code}", Summary: "Report a link for abuse", Tag: "public", Body: model.CreateReportRequest{}, Status: http.StatusAccepted, Response: SuccessResponse{}},
+	{Method: http.MethodGet, Path: "/", Summary: "Redirect to the domain's root URL", Tag: "public", Status: http.StatusFound},
+	{Method: http.MethodGet, Path: "/{code}+", Summary: "Preview a link's destination", Tag: "public", Status: http.StatusOK, ContentType: "text/html"},
+	{Method: http.MethodGet, Path: "/{code}", Summary: "Follow a short link", Tag: "public", Status: http.StatusMovedPermanently},
+}
+
+var openAPIDocument = sync.OnceValues(func() ([]byte, error) {
+	return json.Marshal(buildOpenAPI(apiOperations))
+})
+
+func (h *URLHandler) OpenAPI(w http.ResponseWriter, r *http.Request) {
+	doc, err := openAPIDocument()
+	if err != nil {
+		h.logger.Error("failed to build openapi document", "error", err)
+		h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(doc)
+}
+
+var pathParamPattern = regexp.MustCompile(`\{([^}]+)\}`)
+
+func buildOpenAPI(ops []apiOperation) map[string]any {
+	schemas := map[string]any{}
+	paths := map[string]map[string]any{}
+
+	for _, op := range ops {
+		operation := map[string]any{
+			"summary":     op.Summary,
+			"operationId": operationID(op),
+			"tags":        []string{op.Tag},
+		}
+
+		var params []map[string]any
+		for _, match := range pathParamPattern.FindAllStringSubmatch(op.Path, -1) {
+			params = append(params, map[string]any{
+				"name":     match[1],
+				"in":       "path",
+				"required": true,
+				"schema":   map[string]any{"type": "string"},
+			})
+		}
+
+		query := op.Query
+		if strings.HasPrefix(op.Path, "/api/v1/") && strings.Contains(op.Path, "{code}") {
+			query = append(query, domainParam)
+		}
+
+		for _, param := range query {
+			params = append(params, map[string]any{
+				"name":        param.Name,
+				"in":          "query",
+				"description": param.Description,
+				"schema":      map[string]any{"type": "string"},
+			})
+		}
+
+		for _, param := range op.Headers {
+			params = append(params, map[string]any{
+				"name":        param.Name,
+				"in":          "header",
+				"description": param.Description,
+				"schema":      map[string]any{"type": "string"},
+			})
+		}
+
+		if len(params) > 0 {
+			operation["parameters"] = params
+		}
+
+		if op.Body != nil {
+			operation["requestBody"] = map[string]any{
+				"required": true,
+				"content": map[string]any{
+					"application/json": map[string]any{"schema": schemaFor(reflect.TypeOf(op.Body), schemas)},
+				},
+			}
+		}
+
+		response := map[string]any{"description": http.StatusText(op.Status)}
+		switch {
+		case op.Response != nil:
+			response["content"] = map[string]any{
+				"application/json": map[string]any{"schema": schemaFor(reflect.TypeOf(op.Response), schemas)},
+			}
+		case op.ContentType != "":
+			response["content"] = map[string]any{op.ContentType: map[string]any{}}
+		}
+
+		operation["responses"] = map[string]any{
+			strconv.Itoa(op.Status): response,
+			"default": map[string]any{
+				"description": "Error",
+				"content": map[string]any{
+					"application/problem+json": map[string]any{"schema": schemaFor(reflect.TypeOf(Problem{}), schemas)},
+				},
+			},
+		}
+
+		if op.Admin {
+			operation["security"] = []map[string][]string{{"bearerAuth": {}}}
+		}
+
+		if paths[op.Path] == nil {
+			paths[op.Path] = map[string]any{}
+		}
+
+		paths[op.Path][strings.ToLower(op.Method)] = operation
+	}
+
+	return map[string]any{
+		"openapi": "3.1.0",
+		"info": map[string]any{
+			"title":   "URL Shortener API",
+			"version": "1.0.0",
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": schemas,
+			"securitySchemes": map[string]any{
+				"bearerAuth": map[string]any{"type": "http", "scheme": "bearer"},
+			},
+		},
+	}
+}
+
+// operationID derives a stable identifier such as postApiV1Shorten.
+func operationID(op apiOperation) string {
+	id := strings.ToLower(op.Method)
+
+	for _, part := range strings.FieldsFunc(op.Path, func(r rune) bool { return !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9') }) {
+		id += strings.ToUpper(part[:1]) + part[1:]
+	}
+
+	if strings.HasSuffix(op.Path, "+") {
+		id += "Preview"
+	}
+
+	return id
+}
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	uuidType = reflect.TypeOf(uuid.UUID{})
+)
+
+// schemaFor returns the JSON schema of a Go type, registering named structs
+// as components and referencing them.
+func schemaFor(t reflect.Type, schemas map[string]any) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t {
+	case timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case uuidType:
+		return map[string]any{"type": "string", "format": "uuid"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": schemaFor(t.Elem(), schemas)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": schemaFor(t.Elem(), schemas)}
+	case reflect.Struct:
+		ref := map[string]any{"$ref": "#/components/schemas/" + t.Name()}
+		if _, ok := schemas[t.Name()]; ok {
+			return ref
+		}
+
+		// Registered before the fields are walked so recursive types terminate.
+		schemas[t.Name()] = nil
+
+		properties := map[string]any{}
+		var required []string
+		addStructFields(t, properties, &required, schemas)
+
+		schema := map[string]any{"type": "object", "properties": properties}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+
+		schemas[t.Name()] = schema
+
+		return ref
+	default:
+		return map[string]any{}
+	}
+}
+
+func addStructFields(t reflect.Type, properties map[string]any, required *[]string, schemas map[string]any) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			addStructFields(field.Type, properties, required, schemas)
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		rules := fieldRules(field.Tag.Get("validate"))
+
+		schema := schemaFor(field.Type, schemas)
+		if _, isRef := schema["$ref"]; !isRef {
+			applyValidation(schema, rules)
+		}
+
+		// Request fields are required when validated as such; response
+		// fields are always present unless they are omitted when empty.
+		omitted := strings.Contains(opts, "omitzero") || strings.Contains(opts, "omitempty")
+		if slices.Contains(rules, "required") || (field.Tag.Get("validate") == "" && !omitted) {
+			*required = append(*required, name)
+		}
+
+		properties[name] = schema
+	}
+}
+
+// fieldRules returns the validator rules that apply to the field itself
+// rather than to its elements.
+func fieldRules(tag string) []string {
+	if tag == "" {
+		return nil
+	}
+
+	rules := strings.Split(tag, ",")
+	if i := slices.Index(rules, "dive"); i >= 0 {
+		rules = rules[:i]
+	}
+
+	return rules
+}
+
+// applyValidation maps validator rules onto the schema.
+func applyValidation(schema map[string]any, rules []string) {
+	for _, rule := range rules {
+		name, param, _ := strings.Cut(rule, "=")
+
+		switch name {
+		case "url":
+			schema["format"] = "uri"
+		case "fqdn":
+			schema["format"] = "hostname"
+		case "alphanum":
+			schema["pattern"] = "^[a-zA-Z0-9]*$"
+		case "oneof":
+			schema["enum"] = strings.Fields(param)
+		case "min", "max":
+			n, err := strconv.Atoi(param)
+			if err != nil {
+				continue
+			}
+
+			schema[boundKeyword(schema["type"], name)] = n
+		}
+	}
+}
+
+func boundKeyword(schemaType any, rule string) string {
+	bound := map[string]string{"min": "minimum", "max": "maximum"}[rule]
+
+	switch schemaType {
+	case "string":
+		bound = map[string]string{"min": "minLength", "max": "maxLength"}[rule]
+	case "array":
+		bound = map[string]string{"min": "minItems", "max": "maxItems"}[rule]
+	case "object":
+		bound = map[string]string{"min": "minProperties", "max": "maxProperties"}[rule]
+	}
+
+	return bound
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// TestOpenAPICoversRoutes fails when a route is added to Routes without an
+// entry in apiOperations, or an entry outlives its route.
+func TestOpenAPICoversRoutes(t *testing.T) {
+	logger := slog.New(slog.DiscardHandler)
+
+	router := Routes(
+		NewURLHandler(nil, nil, nil, 0, logger),
+		NewModerationHandler(nil, logger),
+		NewAuditHandler(nil, logger),
+		NewDomainHandler(nil, logger),
+		NewWebhookHandler(nil, logger),
+		NewGraphQLHandler(nil, logger),
+		nil,
+		"",
+		logger,
+	)
+
+	documented := map[string]bool{}
+	for _, op := range apiOperations {
+		documented[op.Method+" "+op.Path] = true
+	}
+
+	err := chi.Walk(router.(chi.Routes), func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		key := method + " " + route
+		if !documented[key] {
+			t.Errorf("route %s is missing from apiOperations", key)
+		}
+
+		delete(documented, key)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("walk routes: %v", err)
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(documented)) {
+		t.Errorf("apiOperations lists %s, which is not a registered route", key)
+	}
+}
+
+func TestOpenAPIDocument(t *testing.T) {
+	doc, err := openAPIDocument()
+	if err != nil {
+		t.Fatalf("build document: %v", err)
+	}
+
+	var parsed struct {
+		OpenAPI string                    `json:"openapi"`
+		Paths   map[string]map[string]any `json:"paths"`
+	}
+	if err := json.Unmarshal(doc, &parsed); err != nil {
+		t.Fatalf("decode document: %v", err)
+	}
+
+	if parsed.OpenAPI != "3.1.0" {
+		t.Errorf("openapi = %q, want 3.1.0", parsed.OpenAPI)
+	}
+
+	for _, op := range apiOperations {
+		if _, ok := parsed.Paths[op.Path][strings.ToLower(op.Method)]; !ok {
+			t.Errorf("document has no %s %s operation", op.Method, op.Path)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ifaisalabid1/url-shortener/internal/model"
+)
+
+//go:embed templates/*.html
+var templateFS embed.FS
+
+var templates = template.Must(template.ParseFS(templateFS, "templates/*.html"))
+
+// Pages shown when a link cannot be served.
+const (
+	pageNotFound = "not_found"
+	pageExpired  = "expired"
+	pageDisabled = "disabled"
+	pageBanned   = "banned"
+)
+
+type unavailablePage struct {
+	Title     string
+	Message   string
+	ShortCode string
+	Domain    string
+}
+
+var unavailablePages = map[string]unavailablePage{
+	pageNotFound: {
+		Title:   "Link not found",
+		Message: "This short link does not exist.",
+	},
+	pageExpired: {
+		Title:   "Link expired",
+		Message: "This short link has expired.",
+	},
+	pageDisabled: {
+		Title:   "Link disabled",
+		Message: "This short link has been disabled.",
+	},
+	pageBanned: {
+		Title:   "Link removed",
+		Message: "This short link has been removed for violating our terms of use.",
+	},
+}
+
+// fallbackURL returns where browsers are redirected instead of seeing the
+// named page, if anywhere.
+func fallbackURL(fallbacks model.DomainFallbacks, name string) string {
+	switch name {
+	case pageNotFound:
+		return fallbacks.NotFoundURL
+	case pageExpired:
+		return fallbacks.ExpiredURL
+	case pageDisabled:
+		return fallbacks.DisabledURL
+	default:
+		return ""
+	}
+}
+
+// catchAllURL expands the catch-all template for the request, keeping its
+// query string. It returns "" if the domain has no catch-all.
+func catchAllURL(fallbacks model.DomainFallbacks, r *http.Request) string {
+	if fallbacks.CatchAllURL == "" {
+		return ""
+	}
+
+	target := strings.ReplaceAll(fallbacks.CatchAllURL, "{path}", strings.TrimPrefix(r.URL.EscapedPath(), "/"))
+
+	if r.URL.RawQuery != "" {
+		separator := "?"
+		if strings.Contains(target, "?") {
+			separator = "&"
+		}
+
+		target += separator + r.URL.RawQuery
+	}
+
+	return target
+}
+
+// Pages holds custom templates for the pages above, named after the page,
+// e.g. not_found.html. Templates at the root of the directory replace the
+// built-in page for every domain, and templates in a subdirectory named after
+// a branded host, e.g. go.acme.com/not_found.html, apply to that domain only.
+type Pages struct {
+	templates map[string]*template.Template
+}
+
+func LoadPages(dir string) (*Pages, error) {
+	pages := &Pages{templates: map[string]*template.Template{}}
+	if dir == "" {
+		return pages, nil
+	}
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".html" {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		domain, file := filepath.Split(filepath.ToSlash(rel))
+		if strings.Count(domain, "/") > 1 {
+			return nil
+		}
+
+		tmpl, err := template.ParseFiles(path)
+		if err != nil {
+			return fmt.Errorf("failed to parse page %s: %w", rel, err)
+		}
+
+		pages.templates[strings.TrimSuffix(domain, "/")+"/"+strings.TrimSuffix(file, ".html")] = tmpl
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pages, nil
+}
+
+// lookup returns the custom template for the page on a domain, falling back
+// to the one shared by all domains. It returns nil if there is neither.
+func (p *Pages) lookup(domain, name string) *template.Template {
+	if tmpl, ok := p.templates[domain+"/"+name]; ok {
+		return tmpl
+	}
+
+	return p.templates["/"+name]
+}
+
+// acceptsHTML reports whether the client is a browser asking for a page
+// rather than an API client.
+func acceptsHTML(r *http.Request) bool {
+	accept := r.Header.Get("Accept")
+	return strings.Contains(accept, "text/html") || strings.Contains(accept, "application/xhtml+xml")
+}
+
+type interstitialPage struct {
+	Destination string
+	Host        string
+	Countdown   int
+}
+
+func newInterstitialPage(redirect *model.Redirect, countdown time.Duration) interstitialPage {
+	page := interstitialPage{
+		Destination: redirect.Destination,
+		Host:        redirect.Destination,
+		Countdown:   int(countdown.Seconds()),
+	}
+
+	if u, err := url.Parse(redirect.Destination); err == nil && u.Host != "" {
+		page.Host = u.Host
+	}
+
+	return page
+}
+
+// crawlerAgents are User-Agent fragments of the bots that unfurl links.
+var crawlerAgents = []string{
+	"facebookexternalhit",
+	"facebot",
+	"twitterbot",
+	"slackbot",
+	"linkedinbot",
+	"discordbot",
+	"whatsapp",
+	"telegrambot",
+	"skypeuripreview",
+	"pinterest",
+	"redditbot",
+	"embedly",
+	"mastodon",
+}
+
+func isCrawler(r *http.Request) bool {
+	userAgent := strings.ToLower(r.UserAgent())
+	if userAgent == "" {
+		return false
+	}
+
+	for _, agent := range crawlerAgents {
+		if strings.Contains(userAgent, agent) {
+			return true
+		}
+	}
+
+	return false
+}
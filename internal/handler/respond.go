@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"html/template"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/ifaisalabid1/url-shortener/internal/model"
+)
+
+// problemTypeValidation identifies problems carrying field-level errors.
+const problemTypeValidation = "/problems/validation-error"
+
+// Problem is an RFC 9457 problem details error response.
+type Problem struct {
+	Type      string             `json:"type"`
+	Title     string             `json:"title"`
+	Status    int                `json:"status"`
+	Detail    string             `json:"detail,omitzero"`
+	Instance  string             `json:"instance,omitzero"`
+	RequestID string             `json:"request_id,omitzero"`
+	Errors    []model.FieldError `json:"errors,omitzero"`
+}
+
+type SuccessResponse struct {
+	Message string `json:"message"`
+	Data    any    `json:"data,omitzero"`
+}
+
+// responder writes JSON and HTML responses on behalf of the handlers.
+type responder struct {
+	logger *slog.Logger
+}
+
+func (h *responder) respondWithJSON(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+
+	w.WriteHeader(status)
+
+	js, err := json.Marshal(data)
+	if err != nil {
+		h.logger.Error("Failed to encode response", "error", err)
+	}
+
+	w.Write(js)
+}
+
+func newProblem(r *http.Request, status int, detail string) *Problem {
+	return &Problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    detail,
+		Instance:  r.URL.Path,
+		RequestID: middleware.GetReqID(r.Context()),
+	}
+}
+
+func (h *responder) respondWithProblem(w http.ResponseWriter, problem *Problem) {
+	js, err := json.Marshal(problem)
+	if err != nil {
+		h.logger.Error("Failed to encode response", "error", err)
+	}
+
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(problem.Status)
+	w.Write(js)
+}
+
+func (h *responder) respondWithError(w http.ResponseWriter, r *http.Request, status int, message string) {
+	h.respondWithProblem(w, newProblem(r, status, message))
+}
+
+// respondWithValidationError reports each field rejected by the validator.
+// Other errors are reported as a plain bad request.
+func (h *responder) respondWithValidationError(w http.ResponseWriter, r *http.Request, err error) {
+	fieldErrors := model.FieldErrors(err)
+	if fieldErrors == nil {
+		h.respondWithError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	problem := newProblem(r, http.StatusBadRequest, "one or more fields are invalid")
+	problem.Type = problemTypeValidation
+	problem.Title = "Validation failed"
+	problem.Errors = fieldErrors
+
+	h.respondWithProblem(w, problem)
+}
+
+func (h *responder) renderHTML(w http.ResponseWriter, r *http.Request, status int, name string, data any) {
+	tmpl := templates.Lookup(name)
+	if tmpl == nil {
+		h.logger.Error("Failed to render template", "template", name, "error", "template not found")
+		h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	h.renderTemplate(w, r, status, tmpl, data)
+}
+
+func (h *responder) renderTemplate(w http.ResponseWriter, r *http.Request, status int, tmpl *template.Template, data any) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		h.logger.Error("Failed to render template", "template", tmpl.Name(), "error", err)
+		h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(buf.Bytes())
+}
@@ -8,15 +8,18 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
+	"github.com/ifaisalabid1/url-shortener/internal/service"
 )
 
-func Routes(urlHandler *URLHandler, logger *slog.Logger) http.Handler {
+func Routes(urlHandler *URLHandler, moderationHandler *ModerationHandler, auditHandler *AuditHandler, domainHandler *DomainHandler, webhookHandler *WebhookHandler, graphqlHandler *GraphQLHandler, idempotencyService service.IdempotencyService, adminAPIKey string, logger *slog.Logger) http.Handler {
 	r := chi.NewRouter()
 
 	r.Use(middleware.RequestID)
 	r.Use(middleware.RealIP)
-	r.Use(middleware.Recoverer)
-	r.Use(middleware.Timeout(60 * time.Second))
+	r.Use(identify(adminAPIKey))
+	r.Use(audit(auditHandler.auditService, logger))
+	r.Use(recoverer(logger))
+	r.Use(timeout(60*time.Second, logger))
 
 	r.Use(func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -42,21 +45,82 @@ func Routes(urlHandler *URLHandler, logger *slog.Logger) http.Handler {
 
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"*"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Link"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "Idempotency-Key", "Last-Event-ID", "X-CSRF-Token"},
+		ExposedHeaders:   []string{"Idempotent-Replayed", "Link"},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
 
 	r.Get("/health", urlHandler.HealthCheck)
+	r.Get("/api/openapi.json", urlHandler.OpenAPI)
+	r.Post("/api/graphql", graphqlHandler.Query)
 
 	r.Route("/api/v1", func(r chi.Router) {
-		r.Post("/shorten", urlHandler.CreateShortURL)
+		r.Use(queryDomain(domainHandler.domainService, logger))
+
+		r.With(idempotent(idempotencyService, logger)).Post("/shorten", urlHandler.CreateShortURL)
 		r.Get("/stats/{code}", urlHandler.GetURLStats)
+		r.Get("/stats/{code}/live", urlHandler.LiveStats)
+		r.Get("/urls/{code}/qr", urlHandler.GetQRCode)
+		r.Get("/urls/{code}/aliases", urlHandler.ListAliases)
+
+		// Links have no owner to check a caller against, so listing or
+		// changing existing links needs the admin API key.
+		r.Group(func(r chi.Router) {
+			r.Use(requireAdmin(adminAPIKey, logger))
+
+			r.Get("/tags", urlHandler.ListTagStats)
+			r.Get("/urls", urlHandler.ListURLs)
+			r.Get("/urls/broken", urlHandler.ListBrokenURLs)
+			r.Patch("/urls/{code}", urlHandler.UpdateURL)
+			r.Post("/urls/{code}/aliases", urlHandler.CreateAlias)
+			r.Delete("/urls/{code}/aliases/{alias}", urlHandler.DeleteAlias)
+			r.Delete("/urls/{code}", urlHandler.DeleteURL)
+			r.Get("/urls/{id}/history", urlHandler.GetURLHistory)
+			r.Post("/urls/{id}/history/{version}/revert", urlHandler.RevertURL)
+
+			r.Get("/trash", urlHandler.ListTrash)
+			r.Post("/trash/{code}/restore", urlHandler.RestoreURL)
+			r.Delete("/trash/{code}", urlHandler.PurgeURL)
+		})
+
+		r.Route("/admin", func(r chi.Router) {
+			r.Use(requireAdmin(adminAPIKey, logger))
+
+			r.Get("/reports", moderationHandler.ListReports)
+			r.Post("/reports/{id}/resolve", moderationHandler.ResolveReport)
+			r.Put("/urls/{code}/status", moderationHandler.SetURLStatus)
+			r.Get("/banned-domains", moderationHandler.ListBannedDomains)
+			r.Post("/banned-domains", moderationHandler.BanDomain)
+			r.Delete("/banned-domains/{domain}", moderationHandler.UnbanDomain)
+			r.Get("/audit", auditHandler.ListAuditLog)
+			r.Get("/audit/export", auditHandler.ExportAuditLog)
+			r.Get("/domains", domainHandler.ListDomains)
+			r.Post("/domains", domainHandler.RegisterDomain)
+			r.Put("/domains/{host}", domainHandler.UpdateDomain)
+			r.Delete("/domains/{host}", domainHandler.RemoveDomain)
+			r.Get("/webhooks", webhookHandler.ListWebhooks)
+			r.Post("/webhooks", webhookHandler.CreateWebhook)
+			r.Delete("/webhooks/{id}", webhookHandler.DeleteWebhook)
+			r.Get("/webhooks/deliveries", webhookHandler.ListDeliveries)
+			r.Get("/webhooks/deliveries/{id}/attempts", webhookHandler.ListAttempts)
+			r.Post("/webhooks/deliveries/{id}/retry", webhookHandler.RetryDelivery)
+		})
 	})
 
-	r.Get("/{code}", urlHandler.RedirectURL)
+	r.NotFound(hostDomain(domainHandler.domainService)(http.HandlerFunc(urlHandler.NotFound)).ServeHTTP)
+	r.MethodNotAllowed(methodNotAllowed(r, logger))
+
+	r.Group(func(r chi.Router) {
+		r.Use(hostDomain(domainHandler.domainService))
+
+		r.Get("/", urlHandler.RootRedirect)
+		r.Post("/report/{code}", moderationHandler.ReportURL)
+
+		r.Get("/{code}+", urlHandler.PreviewURL)
+		r.Get("/{code}", urlHandler.RedirectURL)
+	})
 
 	return r
 }
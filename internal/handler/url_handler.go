@@ -3,49 +3,52 @@ package handler
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
 	"github.com/ifaisalabid1/url-shortener/internal/model"
 	"github.com/ifaisalabid1/url-shortener/internal/repository"
 	"github.com/ifaisalabid1/url-shortener/internal/service"
 )
 
 type URLHandler struct {
-	urlService service.URLService
-	validator  *validator.Validate
-	logger     *slog.Logger
+	responder
+	urlService    service.URLService
+	domainService service.DomainService
+	pages         *Pages
+	validator     *validator.Validate
+	countdown     time.Duration
 }
 
-func NewURLHandler(urlService service.URLService, logger *slog.Logger) *URLHandler {
+func NewURLHandler(urlService service.URLService, domainService service.DomainService, pages *Pages, countdown time.Duration, logger *slog.Logger) *URLHandler {
 	return &URLHandler{
-		urlService: urlService,
-		validator:  validator.New(),
-		logger:     logger,
+		responder:     responder{logger: logger},
+		urlService:    urlService,
+		domainService: domainService,
+		pages:         pages,
+		validator:     validator.New(),
+		countdown:     countdown,
 	}
 }
 
-type ErrorResponse struct {
-	Error string `json:"error"`
-}
-
-type SuccessResponse struct {
-	Message string `json:"message"`
-	Data    any    `json:"data,omitzero"`
-}
-
 func (h *URLHandler) CreateShortURL(w http.ResponseWriter, r *http.Request) {
 	var req model.CreateURLRequest
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondWithError(w, http.StatusBadRequest, "invalid request body")
+		h.respondWithError(w, r, http.StatusBadRequest, "invalid request body")
 		return
 	}
 
 	if err := req.Validate(); err != nil {
-		h.respondWithError(w, http.StatusOK, err.Error())
+		h.respondWithValidationError(w, r, err)
 		return
 	}
 
@@ -53,35 +56,202 @@ func (h *URLHandler) CreateShortURL(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		switch {
 		case errors.Is(err, repository.ErrDuplicateCode):
-			h.respondWithError(w, http.StatusConflict, "short code already exists")
+			h.respondWithError(w, r, http.StatusConflict, "short code already exists")
+		case errors.Is(err, service.ErrInvalidURL):
+			h.respondWithError(w, r, http.StatusBadRequest, err.Error())
+		case errors.Is(err, service.ErrDestinationRejected):
+			h.respondWithError(w, r, http.StatusUnprocessableEntity, err.Error())
+		case errors.Is(err, service.ErrUnknownDomain),
+			errors.Is(err, service.ErrInterstitialOptOut):
+			h.respondWithError(w, r, http.StatusBadRequest, err.Error())
 		default:
 			h.logger.Error("failed to create url", "error", err)
-			h.respondWithError(w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
 		}
 
 		return
 	}
 
+	if res.Reused {
+		h.respondWithJSON(w, http.StatusOK, res)
+		return
+	}
+
 	h.respondWithJSON(w, http.StatusCreated, res)
 }
 
+func (h *URLHandler) UpdateURL(w http.ResponseWriter, r *http.Request) {
+	shortCode := chi.URLParam(r, "code")
+
+	var req model.UpdateURLRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.respondWithValidationError(w, r, err)
+		return
+	}
+
+	res, err := h.urlService.UpdateURL(r.Context(), shortCode, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrURLNotFound):
+			h.respondWithError(w, r, http.StatusNotFound, "url not found")
+		case errors.Is(err, service.ErrInvalidURL):
+			h.respondWithError(w, r, http.StatusBadRequest, err.Error())
+		case errors.Is(err, service.ErrDestinationRejected):
+			h.respondWithError(w, r, http.StatusUnprocessableEntity, err.Error())
+		default:
+			h.logger.Error("failed to update url", "error", err)
+			h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		}
+
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, res)
+}
+
 func (h *URLHandler) RedirectURL(w http.ResponseWriter, r *http.Request) {
 	shortCode := chi.URLParam(r, "code")
 
-	original_url, err := h.urlService.GetOriginalURL(r.Context(), shortCode)
+	if isCrawler(r) {
+		// Flagged links fall through to the warning page below, which never
+		// sends the crawler on to the destination.
+		preview, err := h.urlService.GetURLPreview(r.Context(), shortCode)
+		if err == nil && preview.OpenGraph != nil && !preview.Flagged {
+			h.renderHTML(w, r, http.StatusOK, "opengraph.html", preview)
+			return
+		}
+	}
+
+	redirect, err := h.urlService.GetOriginalURL(r.Context(), shortCode, r.Header.Get("Accept-Language"))
 	if err != nil {
 		switch {
 		case errors.Is(err, repository.ErrURLNotFound):
-			h.respondWithError(w, http.StatusNotFound, "url not found")
+			h.redirectUnmatched(w, r)
+		case errors.Is(err, service.ErrURLExpired):
+			h.respondUnavailable(w, r, http.StatusGone, pageExpired, "url expired")
+		case errors.Is(err, service.ErrURLDisabled):
+			h.respondUnavailable(w, r, http.StatusGone, pageDisabled, "url disabled")
+		case errors.Is(err, service.ErrURLBanned):
+			h.respondUnavailable(w, r, http.StatusUnavailableForLegalReasons, pageBanned, "url banned")
 		default:
 			h.logger.Error("failed to get original url", "error", err)
-			h.respondWithError(w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
 		}
 
 		return
 	}
 
-	http.Redirect(w, r, original_url, http.StatusMovedPermanently)
+	if redirect.Flagged {
+		h.renderHTML(w, r, http.StatusOK, "warning.html", redirect)
+		return
+	}
+
+	if redirect.Interstitial {
+		h.renderHTML(w, r, http.StatusOK, "interstitial.html", newInterstitialPage(redirect, h.countdown))
+		return
+	}
+
+	status := http.StatusMovedPermanently
+	if redirect.Negotiated {
+		status = http.StatusFound
+	}
+
+	w.Header().Add("Vary", "Accept-Language")
+	http.Redirect(w, r, redirect.Destination, status)
+}
+
+// RootRedirect sends visitors of a bare short domain to its root URL.
+func (h *URLHandler) RootRedirect(w http.ResponseWriter, r *http.Request) {
+	fallbacks := h.domainService.Fallbacks(service.DomainFromContext(r.Context()))
+
+	if fallbacks.RootURL != "" {
+		http.Redirect(w, r, fallbacks.RootURL, http.StatusFound)
+		return
+	}
+
+	h.redirectUnmatched(w, r)
+}
+
+// NotFound handles paths no route matches. Page requests on a short domain go
+// to its catch-all; API and non-GET requests get a plain JSON 404.
+func (h *URLHandler) NotFound(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, "/api/") || (r.Method != http.MethodGet && r.Method != http.MethodHead) {
+		h.respondWithError(w, r, http.StatusNotFound, "not found")
+		return
+	}
+
+	h.redirectUnmatched(w, r)
+}
+
+// redirectUnmatched forwards a path that matches no link to the domain's
+// catch-all URL, or explains that the link does not exist.
+func (h *URLHandler) redirectUnmatched(w http.ResponseWriter, r *http.Request) {
+	fallbacks := h.domainService.Fallbacks(service.DomainFromContext(r.Context()))
+
+	if target := catchAllURL(fallbacks, r); target != "" {
+		http.Redirect(w, r, target, http.StatusFound)
+		return
+	}
+
+	h.respondUnavailable(w, r, http.StatusNotFound, pageNotFound, "url not found")
+}
+
+// respondUnavailable explains why a link cannot be served. Browsers are sent
+// to the domain's fallback URL or shown a page, and API clients get JSON.
+func (h *URLHandler) respondUnavailable(w http.ResponseWriter, r *http.Request, status int, name, message string) {
+	w.Header().Add("Vary", "Accept")
+
+	if !acceptsHTML(r) {
+		h.respondWithError(w, r, status, message)
+		return
+	}
+
+	domain := service.DomainFromContext(r.Context())
+
+	if target := fallbackURL(h.domainService.Fallbacks(domain), name); target != "" {
+		http.Redirect(w, r, target, http.StatusFound)
+		return
+	}
+
+	page := unavailablePages[name]
+	page.ShortCode = chi.URLParam(r, "code")
+	page.Domain = domain
+
+	if tmpl := h.pages.lookup(domain, name); tmpl != nil {
+		h.renderTemplate(w, r, status, tmpl, page)
+		return
+	}
+
+	h.renderHTML(w, r, status, "unavailable.html", page)
+}
+
+func (h *URLHandler) PreviewURL(w http.ResponseWriter, r *http.Request) {
+	shortCode := chi.URLParam(r, "code")
+
+	preview, err := h.urlService.GetURLPreview(r.Context(), shortCode)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrURLNotFound):
+			h.respondWithError(w, r, http.StatusNotFound, "url not found")
+		case errors.Is(err, service.ErrURLDisabled):
+			h.respondWithError(w, r, http.StatusGone, "url disabled")
+		case errors.Is(err, service.ErrURLBanned):
+			h.respondWithError(w, r, http.StatusUnavailableForLegalReasons, "url banned")
+		default:
+			h.logger.Error("failed to get url preview", "error", err)
+			h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		}
+
+		return
+	}
+
+	h.renderHTML(w, r, http.StatusOK, "preview.html", preview)
 }
 
 func (h *URLHandler) GetURLStats(w http.ResponseWriter, r *http.Request) {
@@ -91,10 +261,10 @@ func (h *URLHandler) GetURLStats(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		switch {
 		case errors.Is(err, repository.ErrURLNotFound):
-			h.respondWithError(w, http.StatusNotFound, "url not found")
+			h.respondWithError(w, r, http.StatusNotFound, "url not found")
 		default:
 			h.logger.Error("Failed to get url stats", "error", err)
-			h.respondWithError(w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
 		}
 
 		return
@@ -103,23 +273,422 @@ func (h *URLHandler) GetURLStats(w http.ResponseWriter, r *http.Request) {
 	h.respondWithJSON(w, http.StatusOK, stats)
 }
 
-func (h *URLHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
-	h.respondWithJSON(w, http.StatusOK, SuccessResponse{Message: "service is healthy"})
+// liveHeartbeat is how often an idle click stream sends a comment, keeping
+// proxies from closing the connection.
+const liveHeartbeat = 15 * time.Second
+
+// LiveStats streams a link's clicks as server-sent events. Each event's ID is
+// the link's click count, so a client reconnecting with Last-Event-ID is
+// first sent the recent clicks it missed.
+func (h *URLHandler) LiveStats(w http.ResponseWriter, r *http.Request) {
+	shortCode := chi.URLParam(r, "code")
+
+	var lastEventID int64
+	if v := r.Header.Get("Last-Event-ID"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id < 0 {
+			h.respondWithError(w, r, http.StatusBadRequest, "invalid Last-Event-ID")
+			return
+		}
+
+		lastEventID = id
+	}
+
+	events, err := h.urlService.LiveClicks(r.Context(), shortCode, lastEventID)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrURLNotFound):
+			h.respondWithError(w, r, http.StatusNotFound, "url not found")
+		default:
+			h.logger.Error("failed to subscribe to live stats", "error", err)
+			h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		}
+
+		return
+	}
+
+	// The server's write timeout would otherwise end the stream.
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		h.logger.Error("failed to clear write deadline", "error", err)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	if err := rc.Flush(); err != nil {
+		h.logger.Error("failed to start live stats stream", "error", err)
+		return
+	}
+
+	heartbeat := time.NewTicker(liveHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+
+			data, err := json.Marshal(event)
+			if err != nil {
+				h.logger.Error("failed to encode click event", "error", err)
+				return
+			}
+
+			if _, err := fmt.Fprintf(w, "id: %d\nevent: click\ndata: %s\n\n", event.Clicks, data); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := io.WriteString(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func (h *URLHandler) GetQRCode(w http.ResponseWriter, r *http.Request) {
+	shortCode := chi.URLParam(r, "code")
+	query := r.URL.Query()
+
+	req := model.QRCodeRequest{
+		Format:     strings.ToLower(queryValue(query.Get("format"), "png")),
+		ECC:        strings.ToUpper(queryValue(query.Get("ecc"), "M")),
+		Foreground: "#" + strings.TrimPrefix(queryValue(query.Get("fg"), "000000"), "#"),
+		Background: "#" + strings.TrimPrefix(queryValue(query.Get("bg"), "ffffff"), "#"),
+	}
+
+	var err error
+	if req.Size, err = strconv.Atoi(queryValue(query.Get("size"), "256")); err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, "invalid size")
+		return
+	}
+
+	if req.QuietZone, err = strconv.Atoi(queryValue(query.Get("quiet_zone"), "4")); err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, "invalid quiet_zone")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.respondWithValidationError(w, r, err)
+		return
+	}
+
+	image, err := h.urlService.GetQRCode(r.Context(), shortCode, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrURLNotFound):
+			h.respondWithError(w, r, http.StatusNotFound, "url not found")
+		case errors.Is(err, service.ErrURLExpired):
+			h.respondWithError(w, r, http.StatusGone, "url expired")
+		case errors.Is(err, service.ErrURLDisabled):
+			h.respondWithError(w, r, http.StatusGone, "url disabled")
+		case errors.Is(err, service.ErrURLBanned):
+			h.respondWithError(w, r, http.StatusUnavailableForLegalReasons, "url banned")
+		default:
+			h.logger.Error("failed to generate qr code", "error", err)
+			h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		}
+
+		return
+	}
+
+	contentType := "image/png"
+	if req.Format == "svg" {
+		contentType = "image/svg+xml"
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+	w.WriteHeader(http.StatusOK)
+	w.Write(image)
+}
+
+func (h *URLHandler) CreateAlias(w http.ResponseWriter, r *http.Request) {
+	shortCode := chi.URLParam(r, "code")
+
+	var req model.CreateAliasRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.respondWithValidationError(w, r, err)
+		return
+	}
+
+	res, err := h.urlService.CreateAlias(r.Context(), shortCode, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrURLNotFound):
+			h.respondWithError(w, r, http.StatusNotFound, "url not found")
+		case errors.Is(err, repository.ErrDuplicateCode):
+			h.respondWithError(w, r, http.StatusConflict, "short code already exists")
+		default:
+			h.logger.Error("failed to create alias", "error", err)
+			h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		}
+
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusCreated, res)
+}
+
+func (h *URLHandler) ListAliases(w http.ResponseWriter, r *http.Request) {
+	shortCode := chi.URLParam(r, "code")
+
+	aliases, err := h.urlService.ListAliases(r.Context(), shortCode)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrURLNotFound):
+			h.respondWithError(w, r, http.StatusNotFound, "url not found")
+		default:
+			h.logger.Error("failed to list aliases", "error", err)
+			h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		}
+
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, aliases)
+}
+
+func (h *URLHandler) DeleteAlias(w http.ResponseWriter, r *http.Request) {
+	shortCode := chi.URLParam(r, "code")
+	alias := chi.URLParam(r, "alias")
+
+	if err := h.urlService.DeleteAlias(r.Context(), shortCode, alias); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrURLNotFound):
+			h.respondWithError(w, r, http.StatusNotFound, "url not found")
+		case errors.Is(err, repository.ErrAliasNotFound):
+			h.respondWithError(w, r, http.StatusNotFound, "alias not found")
+		default:
+			h.logger.Error("failed to delete alias", "error", err)
+			h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		}
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
 }
 
-func (h *URLHandler) respondWithJSON(w http.ResponseWriter, status int, data any) {
-	w.Header().Set("Content-Type", "application/json")
+func (h *URLHandler) ListBrokenURLs(w http.ResponseWriter, r *http.Request) {
+	limit, offset, err := pagination(r)
+	if err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	urls, err := h.urlService.ListBrokenURLs(r.Context(), limit, offset)
+	if err != nil {
+		h.logger.Error("failed to list broken urls", "error", err)
+		h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, urls)
+}
+
+func (h *URLHandler) ListURLs(w http.ResponseWriter, r *http.Request) {
+	limit, offset, err := pagination(r)
+	if err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	filter := model.URLFilter{
+		Tag:    r.URL.Query().Get("tag"),
+		Folder: r.URL.Query().Get("folder"),
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	urls, err := h.urlService.ListURLs(r.Context(), filter)
+	if err != nil {
+		h.logger.Error("failed to list urls", "error", err)
+		h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
 
-	w.WriteHeader(status)
+	h.respondWithJSON(w, http.StatusOK, urls)
+}
 
-	js, err := json.Marshal(data)
+func (h *URLHandler) ListTagStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.urlService.ListTagStats(r.Context())
 	if err != nil {
-		h.logger.Error("Failed to encode response", "error", err)
+		h.logger.Error("failed to get tag stats", "error", err)
+		h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
 	}
 
-	w.Write(js)
+	h.respondWithJSON(w, http.StatusOK, stats)
 }
 
-func (h *URLHandler) respondWithError(w http.ResponseWriter, status int, message string) {
-	h.respondWithJSON(w, status, ErrorResponse{Error: message})
+func (h *URLHandler) DeleteURL(w http.ResponseWriter, r *http.Request) {
+	shortCode := chi.URLParam(r, "code")
+
+	if err := h.urlService.DeleteURL(r.Context(), shortCode); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrURLNotFound):
+			h.respondWithError(w, r, http.StatusNotFound, "url not found")
+		default:
+			h.logger.Error("failed to delete url", "error", err)
+			h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		}
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *URLHandler) ListTrash(w http.ResponseWriter, r *http.Request) {
+	limit, offset, err := pagination(r)
+	if err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	urls, err := h.urlService.ListTrash(r.Context(), limit, offset)
+	if err != nil {
+		h.logger.Error("failed to list trash", "error", err)
+		h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, urls)
+}
+
+func (h *URLHandler) RestoreURL(w http.ResponseWriter, r *http.Request) {
+	shortCode := chi.URLParam(r, "code")
+
+	res, err := h.urlService.RestoreURL(r.Context(), shortCode)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrURLNotFound):
+			h.respondWithError(w, r, http.StatusNotFound, "url not found in trash")
+		default:
+			h.logger.Error("failed to restore url", "error", err)
+			h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		}
+
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, res)
+}
+
+func (h *URLHandler) PurgeURL(w http.ResponseWriter, r *http.Request) {
+	shortCode := chi.URLParam(r, "code")
+
+	if err := h.urlService.PurgeURL(r.Context(), shortCode); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrURLNotFound):
+			h.respondWithError(w, r, http.StatusNotFound, "url not found in trash")
+		default:
+			h.logger.Error("failed to purge url", "error", err)
+			h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		}
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *URLHandler) GetURLHistory(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, "invalid url id")
+		return
+	}
+
+	history, err := h.urlService.GetURLHistory(r.Context(), id)
+	if err != nil {
+		h.logger.Error("failed to get url history", "error", err)
+		h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, history)
+}
+
+func (h *URLHandler) RevertURL(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, "invalid url id")
+		return
+	}
+
+	version, err := strconv.Atoi(chi.URLParam(r, "version"))
+	if err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, "invalid version")
+		return
+	}
+
+	res, err := h.urlService.RevertURL(r.Context(), id, version)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrURLNotFound):
+			h.respondWithError(w, r, http.StatusNotFound, "url not found")
+		case errors.Is(err, repository.ErrHistoryNotFound):
+			h.respondWithError(w, r, http.StatusNotFound, "history entry not found")
+		case errors.Is(err, service.ErrNothingToRevert):
+			h.respondWithError(w, r, http.StatusConflict, err.Error())
+		case errors.Is(err, service.ErrInvalidURL):
+			h.respondWithError(w, r, http.StatusBadRequest, err.Error())
+		case errors.Is(err, service.ErrDestinationRejected):
+			h.respondWithError(w, r, http.StatusUnprocessableEntity, err.Error())
+		default:
+			h.logger.Error("failed to revert url", "error", err)
+			h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		}
+
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, res)
+}
+
+func (h *URLHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
+	h.respondWithJSON(w, http.StatusOK, SuccessResponse{Message: "service is healthy"})
+}
+
+func queryValue(value, defaultValue string) string {
+	if value != "" {
+		return value
+	}
+
+	return defaultValue
+}
+
+// pagination reads the limit and offset query parameters.
+func pagination(r *http.Request) (limit, offset int, err error) {
+	query := r.URL.Query()
+
+	limit, err = strconv.Atoi(queryValue(query.Get("limit"), "50"))
+	if err != nil || limit < 1 || limit > 500 {
+		return 0, 0, errors.New("limit must be between 1 and 500")
+	}
+
+	offset, err = strconv.Atoi(queryValue(query.Get("offset"), "0"))
+	if err != nil || offset < 0 {
+		return 0, 0, errors.New("offset must not be negative")
+	}
+
+	return limit, offset, nil
 }
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/ifaisalabid1/url-shortener/internal/model"
+	"github.com/ifaisalabid1/url-shortener/internal/repository"
+	"github.com/ifaisalabid1/url-shortener/internal/service"
+)
+
+type WebhookHandler struct {
+	responder
+	webhookService service.WebhookService
+}
+
+func NewWebhookHandler(webhookService service.WebhookService, logger *slog.Logger) *WebhookHandler {
+	return &WebhookHandler{
+		responder:      responder{logger: logger},
+		webhookService: webhookService,
+	}
+}
+
+func (h *WebhookHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	subs, err := h.webhookService.List(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list webhooks", "error", err)
+		h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, subs)
+}
+
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var req model.CreateWebhookRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.respondWithValidationError(w, r, err)
+		return
+	}
+
+	sub, err := h.webhookService.Create(r.Context(), &req)
+	if err != nil {
+		h.logger.Error("failed to create webhook", "error", err)
+		h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusCreated, sub)
+}
+
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, "invalid webhook id")
+		return
+	}
+
+	if err := h.webhookService.Delete(r.Context(), id); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrWebhookNotFound):
+			h.respondWithError(w, r, http.StatusNotFound, "webhook not found")
+		default:
+			h.logger.Error("failed to delete webhook", "error", err)
+			h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		}
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListDeliveries lists queued and past deliveries. Filtering on status=dead
+// gives the dead-letter queue.
+func (h *WebhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+
+	switch status {
+	case "", model.DeliveryPending, model.DeliveryDelivered, model.DeliveryDead:
+	default:
+		h.respondWithError(w, r, http.StatusBadRequest, "status must be pending, delivered or dead")
+		return
+	}
+
+	limit, offset, err := pagination(r)
+	if err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	deliveries, err := h.webhookService.ListDeliveries(r.Context(), status, limit, offset)
+	if err != nil {
+		h.logger.Error("failed to list webhook deliveries", "error", err)
+		h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, deliveries)
+}
+
+func (h *WebhookHandler) ListAttempts(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, "invalid delivery id")
+		return
+	}
+
+	attempts, err := h.webhookService.ListAttempts(r.Context(), id)
+	if err != nil {
+		h.logger.Error("failed to list webhook attempts", "error", err)
+		h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, attempts)
+}
+
+// RetryDelivery requeues a dead delivery for immediate sending.
+func (h *WebhookHandler) RetryDelivery(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, "invalid delivery id")
+		return
+	}
+
+	if err := h.webhookService.Redeliver(r.Context(), id); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrDeliveryNotFound):
+			h.respondWithError(w, r, http.StatusNotFound, "dead delivery not found")
+		default:
+			h.logger.Error("failed to retry webhook delivery", "error", err)
+			h.respondWithError(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		}
+
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusAccepted, SuccessResponse{Message: "delivery requeued"})
+}
@@ -0,0 +1,27 @@
+package model
+
+// Trust levels of the people creating links.
+const (
+	TrustAnonymous = "anonymous"
+	TrustTrusted   = "trusted"
+)
+
+// ActorSystem names the actor of changes made by background jobs.
+const ActorSystem = "system"
+
+// Actor identifies who is making a request.
+type Actor struct {
+	Name  string `json:"name"`
+	IP    string `json:"ip,omitzero"`
+	Trust string `json:"trust"`
+}
+
+// Scope identifies the actor for per-caller state such as idempotency keys:
+// trusted actors by name, everyone else by IP address.
+func (a *Actor) Scope() string {
+	if a.Trust == TrustTrusted {
+		return a.Name
+	}
+
+	return a.IP
+}
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	AuditSuccess = "success"
+	AuditFailure = "failure"
+	AuditDenied  = "denied"
+)
+
+// AuditEntry records one authenticated mutating API call. Calls made over
+// gRPC have the method "GRPC" and their gRPC status code as the status.
+type AuditEntry struct {
+	ID         uuid.UUID `json:"id" db:"id"`
+	RequestID  string    `json:"request_id,omitzero" db:"request_id"`
+	Actor      string    `json:"actor" db:"actor"`
+	IP         string    `json:"ip,omitzero" db:"ip"`
+	Method     string    `json:"method" db:"method"`
+	Path       string    `json:"path" db:"path"`
+	Route      string    `json:"route,omitzero" db:"route"`
+	Status     int       `json:"status" db:"status"`
+	Outcome    string    `json:"outcome" db:"outcome"`
+	DurationMS int64     `json:"duration_ms" db:"duration_ms"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,67 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// ShortDomain is a branded host that short links can be created on, with
+// its own namespace of short codes.
+type ShortDomain struct {
+	Host      string    `json:"host" db:"host"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+
+	DomainFallbacks
+}
+
+// DomainFallbacks are where browsers are redirected when a code on the domain
+// cannot be served. Empty fields show the built-in or configured page.
+//
+// RootURL is where the bare domain redirects. CatchAllURL is used for any path
+// that matches no link, with {path} replaced by the requested path, e.g.
+// https://example.com/{path}.
+type DomainFallbacks struct {
+	NotFoundURL string `json:"not_found_url,omitzero" db:"not_found_url" validate:"omitempty,url"`
+	ExpiredURL  string `json:"expired_url,omitzero" db:"expired_url" validate:"omitempty,url"`
+	DisabledURL string `json:"disabled_url,omitzero" db:"disabled_url" validate:"omitempty,url"`
+	RootURL     string `json:"root_url,omitzero" db:"root_url" validate:"omitempty,url"`
+	CatchAllURL string `json:"catch_all_url,omitzero" db:"catch_all_url" validate:"omitempty,url"`
+}
+
+type CreateShortDomainRequest struct {
+	Host string `json:"host" validate:"required,fqdn,max=253"`
+
+	DomainFallbacks
+}
+
+// ShortLink identifies a short code on a domain. An empty domain is the
+// default one.
+type ShortLink struct {
+	Domain    string
+	ShortCode string
+}
+
+func (d *CreateShortDomainRequest) Validate() error {
+	validate := newValidator()
+	return validate.Struct(d)
+}
+
+func (f *DomainFallbacks) Validate() error {
+	validate := newValidator()
+	return validate.Struct(f)
+}
+
+// shortURL joins a short code onto its domain, falling back to baseURL for
+// the default domain. Branded domains are served with the base URL's scheme.
+func shortURL(baseURL, domain, shortCode string) string {
+	if domain != "" {
+		scheme, _, ok := strings.Cut(baseURL, "://")
+		if !ok {
+			scheme = "https"
+		}
+
+		baseURL = scheme + "://" + domain
+	}
+
+	return baseURL + "/" + shortCode
+}
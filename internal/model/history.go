@@ -0,0 +1,79 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	HistoryCreate  = "create"
+	HistoryUpdate  = "update"
+	HistoryDelete  = "delete"
+	HistoryRestore = "restore"
+	HistoryPurge   = "purge"
+	HistoryExpire  = "expire"
+	HistoryRevert  = "revert"
+)
+
+// URLSnapshot captures the user-editable state of a URL at one version.
+type URLSnapshot struct {
+	ShortCode       string          `json:"short_code"`
+	OriginalURL     string          `json:"original_url"`
+	ExpiresAt       *time.Time      `json:"expires_at,omitzero"`
+	LanguageTargets LanguageTargets `json:"language_targets,omitzero"`
+	OpenGraph       *OpenGraph      `json:"open_graph,omitzero"`
+	Interstitial    *bool           `json:"interstitial,omitzero"`
+	Folder          string          `json:"folder,omitzero"`
+	Tags            []string        `json:"tags,omitzero"`
+}
+
+func (s *URLSnapshot) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(s)
+}
+
+func (s *URLSnapshot) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, s)
+	case string:
+		return json.Unmarshal([]byte(v), s)
+	default:
+		return fmt.Errorf("cannot scan %T into URLSnapshot", src)
+	}
+}
+
+// HistoryEntry is one append-only record of a change to a URL.
+type HistoryEntry struct {
+	ID        uuid.UUID    `json:"id" db:"id"`
+	URLID     uuid.UUID    `json:"url_id" db:"url_id"`
+	Version   int          `json:"version" db:"version"`
+	Action    string       `json:"action" db:"action"`
+	Actor     string       `json:"actor" db:"actor"`
+	ActorIP   string       `json:"-" db:"actor_ip"`
+	OldValue  *URLSnapshot `json:"old_value,omitzero" db:"old_value"`
+	NewValue  *URLSnapshot `json:"new_value,omitzero" db:"new_value"`
+	CreatedAt time.Time    `json:"created_at" db:"created_at"`
+}
+
+func (u *URL) Snapshot() *URLSnapshot {
+	return &URLSnapshot{
+		ShortCode:       u.ShortCode,
+		OriginalURL:     u.OriginalURL,
+		ExpiresAt:       u.ExpiresAt,
+		LanguageTargets: u.LanguageTargets,
+		OpenGraph:       u.OpenGraph,
+		Interstitial:    u.Interstitial,
+		Folder:          u.Folder,
+		Tags:            u.Tags,
+	}
+}
@@ -0,0 +1,11 @@
+package model
+
+// IdempotentResponse is the stored outcome of a request made with an
+// Idempotency-Key. Completed is false while the first request is running.
+type IdempotentResponse struct {
+	Fingerprint string `json:"fingerprint"`
+	Completed   bool   `json:"completed"`
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type"`
+	Body        []byte `json:"body"`
+}
@@ -0,0 +1,81 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	URLStatusActive   = "active"
+	URLStatusDisabled = "disabled"
+	URLStatusBanned   = "banned"
+)
+
+const (
+	ReportStatusOpen      = "open"
+	ReportStatusResolved  = "resolved"
+	ReportStatusDismissed = "dismissed"
+)
+
+type Report struct {
+	ID         uuid.UUID  `json:"id" db:"id"`
+	URLID      uuid.UUID  `json:"url_id" db:"url_id"`
+	ShortCode  string     `json:"short_code" db:"short_code"`
+	Reason     string     `json:"reason" db:"reason"`
+	Details    string     `json:"details,omitzero" db:"details"`
+	ReporterIP string     `json:"reporter_ip,omitzero" db:"reporter_ip"`
+	Status     string     `json:"status" db:"status"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	ResolvedAt *time.Time `json:"resolved_at,omitzero" db:"resolved_at"`
+}
+
+type CreateReportRequest struct {
+	Reason  string `json:"reason" validate:"required,oneof=phishing malware spam illegal other"`
+	Details string `json:"details,omitzero" validate:"max=2000"`
+}
+
+type ResolveReportRequest struct {
+	Status string `json:"status" validate:"required,oneof=resolved dismissed"`
+}
+
+type SetURLStatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=active disabled banned"`
+	Reason string `json:"reason,omitzero" validate:"max=500"`
+}
+
+type BannedDomain struct {
+	Domain    string    `json:"domain" db:"domain"`
+	Reason    string    `json:"reason,omitzero" db:"reason"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+type BanDomainRequest struct {
+	Domain string `json:"domain" validate:"required,fqdn"`
+	Reason string `json:"reason,omitzero" validate:"max=500"`
+}
+
+type BanDomainResponse struct {
+	Domain      *BannedDomain `json:"domain"`
+	BannedCodes int           `json:"banned_codes"`
+}
+
+func (r *CreateReportRequest) Validate() error {
+	validate := newValidator()
+	return validate.Struct(r)
+}
+
+func (r *ResolveReportRequest) Validate() error {
+	validate := newValidator()
+	return validate.Struct(r)
+}
+
+func (r *SetURLStatusRequest) Validate() error {
+	validate := newValidator()
+	return validate.Struct(r)
+}
+
+func (r *BanDomainRequest) Validate() error {
+	validate := newValidator()
+	return validate.Struct(r)
+}
@@ -1,9 +1,11 @@
 package model
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"time"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 )
 
@@ -15,22 +17,182 @@ type URL struct {
 	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
 	Clicks      int64      `json:"clicks" db:"clicks"`
 	ExpiresAt   *time.Time `json:"expires_at,omitzero" db:"expires_at"`
+
+	LanguageTargets LanguageTargets `json:"language_targets,omitzero" db:"language_targets"`
+	OpenGraph       *OpenGraph      `json:"open_graph,omitzero" db:"open_graph"`
+	NormalizedURL   string          `json:"normalized_url" db:"normalized_url"`
+	URLHash         string          `json:"-" db:"url_hash"`
+	Status          string          `json:"status" db:"status"`
+	StatusReason    string          `json:"status_reason,omitzero" db:"status_reason"`
+	Interstitial    *bool           `json:"interstitial,omitzero" db:"interstitial"`
+	CreatorTrust    string          `json:"creator_trust" db:"creator_trust"`
+	Creator         string          `json:"-" db:"creator"`
+	Health          *LinkHealth     `json:"health,omitzero" db:"health"`
+	DeletedAt       *time.Time      `json:"deleted_at,omitzero" db:"deleted_at"`
+	Domain          string          `json:"domain,omitzero" db:"domain"`
+	Folder          string          `json:"folder,omitzero" db:"folder"`
+	Tags            []string        `json:"tags,omitzero" db:"tags"`
+}
+
+// LanguageTargets maps BCP 47 language tags to alternative destinations.
+type LanguageTargets map[string]string
+
+func (l LanguageTargets) Value() (driver.Value, error) {
+	if len(l) == 0 {
+		return nil, nil
+	}
+
+	return json.Marshal(l)
+}
+
+func (l *LanguageTargets) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, l)
+	case string:
+		return json.Unmarshal([]byte(v), l)
+	default:
+		return fmt.Errorf("cannot scan %T into LanguageTargets", src)
+	}
+}
+
+// OpenGraph holds the metadata served to link-unfurling crawlers.
+type OpenGraph struct {
+	Title       string `json:"title,omitzero" validate:"max=200"`
+	Description string `json:"description,omitzero" validate:"max=500"`
+	Image       string `json:"image,omitzero" validate:"omitempty,url"`
+}
+
+func (o *OpenGraph) Value() (driver.Value, error) {
+	if o == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(o)
+}
+
+func (o *OpenGraph) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, o)
+	case string:
+		return json.Unmarshal([]byte(v), o)
+	default:
+		return fmt.Errorf("cannot scan %T into OpenGraph", src)
+	}
+}
+
+// LinkHealth is the outcome of the most recent destination health check.
+type LinkHealth struct {
+	StatusCode int       `json:"status_code,omitzero"`
+	LatencyMS  int64     `json:"latency_ms"`
+	FinalURL   string    `json:"final_url,omitzero"`
+	Error      string    `json:"error,omitzero"`
+	Broken     bool      `json:"broken"`
+	CheckedAt  time.Time `json:"checked_at"`
+}
+
+func (h *LinkHealth) Value() (driver.Value, error) {
+	if h == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(h)
+}
+
+func (h *LinkHealth) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, h)
+	case string:
+		return json.Unmarshal([]byte(v), h)
+	default:
+		return fmt.Errorf("cannot scan %T into LinkHealth", src)
+	}
 }
 
 type CreateURLRequest struct {
 	OriginalURL string     `json:"original_url" validate:"required,url"`
 	CustomCode  *string    `json:"custom_code,omitzero" validate:"omitzero,max=20,alphanum"`
+	Domain      string     `json:"domain,omitzero" validate:"omitempty,fqdn"`
 	ExpiresAt   *time.Time `json:"expires_at,omitzero"`
+
+	LanguageTargets LanguageTargets `json:"language_targets,omitzero" validate:"omitempty,dive,keys,bcp47_language_tag,endkeys,required,url"`
+	OpenGraph       *OpenGraph      `json:"open_graph,omitzero"`
+	ReuseExisting   *bool           `json:"reuse_existing,omitzero"`
+	Interstitial    *bool           `json:"interstitial,omitzero"`
+	Folder          string          `json:"folder,omitzero" validate:"max=100"`
+	Tags            []string        `json:"tags,omitzero" validate:"max=20,dive,required,max=50"`
+}
+
+// UpdateURLRequest leaves fields that are omitted unchanged. An empty
+// language_targets object removes all language targets, an empty tags list
+// removes all tags and an empty folder moves the link out of its folder.
+type UpdateURLRequest struct {
+	OriginalURL     *string         `json:"original_url,omitzero" validate:"omitnil,url"`
+	ExpiresAt       *time.Time      `json:"expires_at,omitzero"`
+	LanguageTargets LanguageTargets `json:"language_targets,omitzero" validate:"omitempty,dive,keys,bcp47_language_tag,endkeys,required,url"`
+	OpenGraph       *OpenGraph      `json:"open_graph,omitzero"`
+	Interstitial    *bool           `json:"interstitial,omitzero"`
+	Folder          *string         `json:"folder,omitzero" validate:"omitnil,max=100"`
+	Tags            *[]string       `json:"tags,omitzero" validate:"omitnil,max=20,dive,required,max=50"`
+}
+
+// URLFilter narrows a listing of URLs. Empty fields match everything.
+type URLFilter struct {
+	Tag    string
+	Folder string
+	Limit  int
+	Offset int
 }
 
 type URLResponse struct {
 	ID          string     `json:"id"`
 	ShortCode   string     `json:"short_code"`
+	Domain      string     `json:"domain,omitzero"`
 	ShortURL    string     `json:"short_url"`
 	OriginalURL string     `json:"original_url"`
 	CreatedAt   time.Time  `json:"created_at"`
 	Clicks      int64      `json:"clicks"`
 	ExpiresAt   *time.Time `json:"expires_at,omitzero"`
+	Status      string     `json:"status"`
+
+	LanguageTargets LanguageTargets `json:"language_targets,omitzero"`
+	OpenGraph       *OpenGraph      `json:"open_graph,omitzero"`
+	Interstitial    *bool           `json:"interstitial,omitzero"`
+	Health          *LinkHealth     `json:"health,omitzero"`
+	DeletedAt       *time.Time      `json:"deleted_at,omitzero"`
+	Folder          string          `json:"folder,omitzero"`
+	Tags            []string        `json:"tags,omitzero"`
+
+	// Reused is set when creating a link returned an existing identical one.
+	Reused bool `json:"reused,omitzero"`
+
+	// Flagged is set on previews whose destination is on a blocklist.
+	Flagged    bool   `json:"flagged,omitzero"`
+	FlagReason string `json:"flag_reason,omitzero"`
+}
+
+// Redirect describes where a visitor to a short link should be sent.
+type Redirect struct {
+	ShortCode   string
+	Destination string
+	Flagged     bool
+	FlagReason  string
+
+	// Interstitial asks for a "you are leaving" page before redirecting.
+	Interstitial bool
+
+	// Negotiated reports that the destination depends on Accept-Language,
+	// so the redirect must not be cached as permanent.
+	Negotiated bool
 }
 
 type URLStats struct {
@@ -38,15 +200,69 @@ type URLStats struct {
 	OriginalURL string    `json:"original_url"`
 	Clicks      int64     `json:"clicks"`
 	CreatedAt   time.Time `json:"created_at"`
+
+	Folder  string        `json:"folder,omitzero"`
+	Tags    []string      `json:"tags,omitzero"`
+	Aliases []*AliasStats `json:"aliases,omitzero"`
+}
+
+// TagStats aggregates the links carrying a tag.
+type TagStats struct {
+	Tag    string `json:"tag"`
+	Links  int64  `json:"links"`
+	Clicks int64  `json:"clicks"`
+}
+
+// Alias is an extra short code resolving to a canonical URL.
+type Alias struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	URLID     uuid.UUID `json:"url_id" db:"url_id"`
+	Domain    string    `json:"domain,omitzero" db:"domain"`
+	ShortCode string    `json:"short_code" db:"short_code"`
+	Clicks    int64     `json:"clicks" db:"clicks"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+type CreateAliasRequest struct {
+	Alias string `json:"alias" validate:"required,min=3,max=20,alphanum"`
+}
+
+type AliasResponse struct {
+	ShortCode string    `json:"short_code"`
+	ShortURL  string    `json:"short_url"`
+	Clicks    int64     `json:"clicks"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type AliasStats struct {
+	ShortCode string `json:"short_code"`
+	Clicks    int64  `json:"clicks"`
+}
+
+// ClickEvent is one visit to a link, streamed to live stats subscribers.
+// Clicks is the link's total after the visit and doubles as the event ID.
+type ClickEvent struct {
+	ShortCode string    `json:"short_code"`
+	Clicks    int64     `json:"clicks"`
+	ClickedAt time.Time `json:"clicked_at"`
+}
+
+type QRCodeRequest struct {
+	Format     string `json:"format" validate:"oneof=png svg"`
+	Size       int    `json:"size" validate:"min=64,max=2048"`
+	ECC        string `json:"ecc" validate:"oneof=L M Q H"`
+	Foreground string `json:"fg" validate:"rgbhex"`
+	Background string `json:"bg" validate:"rgbhex"`
+	QuietZone  int    `json:"quiet_zone" validate:"min=0,max=16"`
 }
 
 func (u *URL) Validate() error {
-	validate := validator.New()
+	validate := newValidator()
 	return validate.Struct(u)
 }
 
 func (u *CreateURLRequest) Validate() error {
-	validate := validator.New()
+	validate := newValidator()
 	return validate.Struct(u)
 }
 
@@ -54,10 +270,50 @@ func (u *URL) ToResponse(baseURL string) *URLResponse {
 	return &URLResponse{
 		ID:          u.ID.String(),
 		ShortCode:   u.ShortCode,
-		ShortURL:    baseURL + "/" + u.ShortCode,
+		Domain:      u.Domain,
+		ShortURL:    shortURL(baseURL, u.Domain, u.ShortCode),
 		OriginalURL: u.OriginalURL,
 		CreatedAt:   u.CreatedAt,
 		Clicks:      u.Clicks,
 		ExpiresAt:   u.ExpiresAt,
+		Status:      u.Status,
+
+		LanguageTargets: u.LanguageTargets,
+		OpenGraph:       u.OpenGraph,
+		Interstitial:    u.Interstitial,
+		Health:          u.Health,
+		DeletedAt:       u.DeletedAt,
+		Folder:          u.Folder,
+		Tags:            u.Tags,
+	}
+}
+
+// ShortURLFor returns the short URL of the link under shortCode, which may be
+// one of its aliases, on the link's domain.
+func (u *URL) ShortURLFor(baseURL, shortCode string) string {
+	return shortURL(baseURL, u.Domain, shortCode)
+}
+
+func (u *UpdateURLRequest) Validate() error {
+	validate := newValidator()
+	return validate.Struct(u)
+}
+
+func (a *CreateAliasRequest) Validate() error {
+	validate := newValidator()
+	return validate.Struct(a)
+}
+
+func (a *Alias) ToResponse(baseURL string) *AliasResponse {
+	return &AliasResponse{
+		ShortCode: a.ShortCode,
+		ShortURL:  shortURL(baseURL, a.Domain, a.ShortCode),
+		Clicks:    a.Clicks,
+		CreatedAt: a.CreatedAt,
 	}
 }
+
+func (q *QRCodeRequest) Validate() error {
+	validate := newValidator()
+	return validate.Struct(q)
+}
@@ -0,0 +1,116 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// newValidator reports fields by their JSON names so validation errors can
+// be returned to clients as-is.
+func newValidator() *validator.Validate {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+
+		return name
+	})
+
+	validate.RegisterValidation("rgbhex", func(fl validator.FieldLevel) bool {
+		return rgbHexPattern.MatchString(fl.Field().String())
+	})
+
+	return validate
+}
+
+// rgbHexPattern matches the colors QR codes can be drawn in: #rgb or
+// #rrggbb. The validator's hexcolor also allows alpha, which they cannot.
+var rgbHexPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// FieldError describes one request field that failed validation.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// FieldErrors translates the validator's errors into one FieldError per
+// rejected field. It returns nil if err is not a validation error.
+func FieldErrors(err error) []FieldError {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return nil
+	}
+
+	fieldErrors := make([]FieldError, 0, len(validationErrors))
+	for _, fe := range validationErrors {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:   fieldPath(fe),
+			Rule:    fe.Tag(),
+			Message: fieldMessage(fe),
+		})
+	}
+
+	return fieldErrors
+}
+
+// fieldPath drops the request struct's name from the field's namespace,
+// leaving e.g. open_graph.title. Embedded structs are flattened in JSON, so
+// their segments, which keep the Go name, are dropped as well.
+func fieldPath(fe validator.FieldError) string {
+	names := strings.Split(fe.Namespace(), ".")
+	goNames := strings.Split(fe.StructNamespace(), ".")
+
+	var path []string
+	for i := 1; i < len(names)-1; i++ {
+		if names[i] != goNames[i] {
+			path = append(path, names[i])
+		}
+	}
+
+	return strings.Join(append(path, fe.Field()), ".")
+}
+
+func fieldMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "url":
+		return "must be a valid URL"
+	case "fqdn":
+		return "must be a fully qualified domain name"
+	case "hexcolor":
+		return "must be a hex color"
+	case "rgbhex":
+		return "must be a hex color in #rgb or #rrggbb form"
+	case "alphanum":
+		return "must contain only letters and digits"
+	case "bcp47_language_tag":
+		return "must be a BCP 47 language tag"
+	case "oneof":
+		return "must be one of: " + strings.Join(strings.Fields(fe.Param()), ", ")
+	case "min", "max":
+		bound := "at least"
+		if fe.Tag() == "max" {
+			bound = "at most"
+		}
+
+		switch fe.Kind() {
+		case reflect.String:
+			return fmt.Sprintf("must be %s %s characters long", bound, fe.Param())
+		case reflect.Slice, reflect.Map, reflect.Array:
+			return fmt.Sprintf("must contain %s %s items", bound, fe.Param())
+		default:
+			return fmt.Sprintf("must be %s %s", bound, fe.Param())
+		}
+	default:
+		return fmt.Sprintf("failed the %s check", fe.Tag())
+	}
+}
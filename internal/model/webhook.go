@@ -0,0 +1,89 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Webhook event types.
+const (
+	EventLinkCreated        = "link.created"
+	EventLinkUpdated        = "link.updated"
+	EventLinkExpired        = "link.expired"
+	EventLinkClickThreshold = "link.click_threshold"
+)
+
+// Webhook delivery statuses. Dead deliveries ran out of attempts and wait
+// in the dead-letter view to be retried by hand.
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryDead      = "dead"
+)
+
+// WebhookSubscription sends the listed events to URL, signed with Secret.
+type WebhookSubscription struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	URL       string    `json:"url" db:"url"`
+	Secret    string    `json:"secret,omitzero" db:"secret"`
+	Events    []string  `json:"events" db:"events"`
+	Active    bool      `json:"active" db:"active"`
+	Owner     string    `json:"-" db:"owner"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// CreateWebhookRequest registers a subscription. A secret is generated when
+// none is given.
+type CreateWebhookRequest struct {
+	URL    string   `json:"url" validate:"required,url"`
+	Secret string   `json:"secret,omitzero" validate:"omitempty,min=16,max=128"`
+	Events []string `json:"events" validate:"required,min=1,dive,oneof=link.created link.updated link.expired link.click_threshold"`
+}
+
+func (r *CreateWebhookRequest) Validate() error {
+	validate := newValidator()
+	return validate.Struct(r)
+}
+
+// WebhookEvent is the JSON body posted to subscribers.
+type WebhookEvent struct {
+	ID        uuid.UUID `json:"id"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
+
+// ClickThresholdData is the data of a link.click_threshold event.
+type ClickThresholdData struct {
+	Link      *URLResponse `json:"link"`
+	Threshold int64        `json:"threshold"`
+}
+
+// WebhookDelivery is one event queued for one subscription.
+type WebhookDelivery struct {
+	ID             uuid.UUID       `json:"id" db:"id"`
+	SubscriptionID uuid.UUID       `json:"subscription_id" db:"subscription_id"`
+	EventID        uuid.UUID       `json:"event_id" db:"event_id"`
+	Event          string          `json:"event" db:"event"`
+	Payload        json.RawMessage `json:"payload" db:"payload"`
+	Status         string          `json:"status" db:"status"`
+	Attempts       int             `json:"attempts" db:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at" db:"next_attempt_at"`
+	LastStatusCode int             `json:"last_status_code,omitzero" db:"last_status_code"`
+	LastError      string          `json:"last_error,omitzero" db:"last_error"`
+	CreatedAt      time.Time       `json:"created_at" db:"created_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitzero" db:"delivered_at"`
+}
+
+// WebhookAttempt logs one try at sending a delivery.
+type WebhookAttempt struct {
+	ID         uuid.UUID `json:"id" db:"id"`
+	DeliveryID uuid.UUID `json:"delivery_id" db:"delivery_id"`
+	Attempt    int       `json:"attempt" db:"attempt"`
+	StatusCode int       `json:"status_code,omitzero" db:"status_code"`
+	Error      string    `json:"error,omitzero" db:"error"`
+	DurationMS int64     `json:"duration_ms" db:"duration_ms"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ifaisalabid1/url-shortener/internal/model"
+	"github.com/lib/pq"
+)
+
+var ErrAliasNotFound = errors.New("alias not found")
+
+type AliasRepository interface {
+	Create(ctx context.Context, alias *model.Alias) error
+	ListByURLID(ctx context.Context, urlID uuid.UUID) ([]*model.Alias, error)
+	ListByURLIDs(ctx context.Context, urlIDs []uuid.UUID) ([]*model.Alias, error)
+	Delete(ctx context.Context, urlID uuid.UUID, shortCode string) error
+}
+
+type aliasRepository struct {
+	db *sql.DB
+}
+
+func NewAliasRepository(db *sql.DB) AliasRepository {
+	return &aliasRepository{db: db}
+}
+
+// Create inserts an alias unless its code is already used by a link or
+// alias on the same domain.
+func (r *aliasRepository) Create(ctx context.Context, alias *model.Alias) error {
+	query := "INSERT INTO url_aliases (id, url_id, domain, short_code, clicks, created_at) VALUES ($1, $2, $3, $4, $5, $6)"
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := reserveShortCode(ctx, tx, alias.Domain, alias.ShortCode); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, query, alias.ID, alias.URLID, alias.Domain, alias.ShortCode, alias.Clicks, alias.CreatedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			if pqErr.Code == "23505" {
+				return ErrDuplicateCode
+			}
+		}
+
+		return fmt.Errorf("failed to create alias: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to create alias: %w", err)
+	}
+
+	return nil
+}
+
+func (r *aliasRepository) ListByURLID(ctx context.Context, urlID uuid.UUID) ([]*model.Alias, error) {
+	query := `SELECT id, url_id, domain, short_code, clicks, created_at
+			  FROM url_aliases
+			  WHERE url_id = $1
+			  ORDER BY created_at`
+
+	return r.list(ctx, query, urlID)
+}
+
+// ListByURLIDs returns the aliases of several URLs in one query.
+func (r *aliasRepository) ListByURLIDs(ctx context.Context, urlIDs []uuid.UUID) ([]*model.Alias, error) {
+	query := `SELECT id, url_id, domain, short_code, clicks, created_at
+			  FROM url_aliases
+			  WHERE url_id = ANY($1::uuid[])
+			  ORDER BY created_at`
+
+	return r.list(ctx, query, pq.Array(uuidStrings(urlIDs)))
+}
+
+func (r *aliasRepository) list(ctx context.Context, query string, args ...any) ([]*model.Alias, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list aliases: %w", err)
+	}
+	defer rows.Close()
+
+	var aliases []*model.Alias
+	for rows.Next() {
+		var alias model.Alias
+		if err := rows.Scan(&alias.ID, &alias.URLID, &alias.Domain, &alias.ShortCode, &alias.Clicks, &alias.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan alias: %w", err)
+		}
+
+		aliases = append(aliases, &alias)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list aliases: %w", err)
+	}
+
+	return aliases, nil
+}
+
+// uuidStrings formats ids for binding as a Postgres uuid[] parameter.
+func uuidStrings(ids []uuid.UUID) []string {
+	strs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		strs = append(strs, id.String())
+	}
+
+	return strs
+}
+
+func (r *aliasRepository) Delete(ctx context.Context, urlID uuid.UUID, shortCode string) error {
+	query := "DELETE FROM url_aliases WHERE url_id = $1 AND short_code = $2"
+
+	result, err := r.db.ExecContext(ctx, query, urlID, shortCode)
+	if err != nil {
+		return fmt.Errorf("failed to delete alias: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return ErrAliasNotFound
+	}
+
+	return nil
+}
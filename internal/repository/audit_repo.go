@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/ifaisalabid1/url-shortener/internal/model"
+)
+
+type AuditRepository interface {
+	Create(ctx context.Context, entry *model.AuditEntry) error
+	List(ctx context.Context, from, to time.Time, limit, offset int) ([]*model.AuditEntry, error)
+	Each(ctx context.Context, from, to time.Time, fn func(*model.AuditEntry) error) error
+}
+
+type auditRepository struct {
+	db *sql.DB
+}
+
+func NewAuditRepository(db *sql.DB) AuditRepository {
+	return &auditRepository{db: db}
+}
+
+const auditColumns = "id, request_id, actor, ip, method, path, route, status, outcome, duration_ms, created_at"
+
+func (r *auditRepository) Create(ctx context.Context, entry *model.AuditEntry) error {
+	query := "INSERT INTO audit_log (" + auditColumns + ") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)"
+
+	args := []any{entry.ID, entry.RequestID, entry.Actor, entry.IP, entry.Method, entry.Path, entry.Route, entry.Status, entry.Outcome, entry.DurationMS, entry.CreatedAt}
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to create audit entry: %w", err)
+	}
+
+	return nil
+}
+
+func (r *auditRepository) List(ctx context.Context, from, to time.Time, limit, offset int) ([]*model.AuditEntry, error) {
+	var entries []*model.AuditEntry
+
+	query := `SELECT ` + auditColumns + `
+			  FROM audit_log
+			  WHERE created_at >= $1 AND created_at < $2
+			  ORDER BY created_at DESC
+			  LIMIT $3 OFFSET $4`
+
+	err := r.query(ctx, query, []any{from, to, limit, offset}, func(entry *model.AuditEntry) error {
+		entries = append(entries, entry)
+		return nil
+	})
+
+	return entries, err
+}
+
+// Each streams every entry in the time range, oldest first, without loading
+// them all into memory.
+func (r *auditRepository) Each(ctx context.Context, from, to time.Time, fn func(*model.AuditEntry) error) error {
+	query := `SELECT ` + auditColumns + `
+			  FROM audit_log
+			  WHERE created_at >= $1 AND created_at < $2
+			  ORDER BY created_at`
+
+	return r.query(ctx, query, []any{from, to}, fn)
+}
+
+func (r *auditRepository) query(ctx context.Context, query string, args []any, fn func(*model.AuditEntry) error) error {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query audit log: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entry model.AuditEntry
+
+		err := rows.Scan(
+			&entry.ID,
+			&entry.RequestID,
+			&entry.Actor,
+			&entry.IP,
+			&entry.Method,
+			&entry.Path,
+			&entry.Route,
+			&entry.Status,
+			&entry.Outcome,
+			&entry.DurationMS,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to scan audit entry: %w", err)
+		}
+
+		if err := fn(&entry); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to query audit log: %w", err)
+	}
+
+	return nil
+}
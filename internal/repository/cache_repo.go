@@ -7,17 +7,34 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/ifaisalabid1/url-shortener/internal/model"
 	"github.com/redis/go-redis/v9"
 )
 
 type CacheRepository interface {
-	SetURL(ctx context.Context, shortCode string, url *model.URL, ttl time.Duration) error
-	GetURL(ctx context.Context, shortCode string) (*model.URL, error)
-	DeleteURL(ctx context.Context, shortCode string) error
+	SetURL(ctx context.Context, domain, shortCode string, url *model.URL, ttl time.Duration) error
+	GetURL(ctx context.Context, domain, shortCode string) (*model.URL, error)
+	DeleteURL(ctx context.Context, domain, shortCode string) error
 	IncrementClicks(ctx context.Context, shortCode string) error
+	SetQRCode(ctx context.Context, key string, image []byte, ttl time.Duration) error
+	GetQRCode(ctx context.Context, key string) ([]byte, error)
+	ReserveIdempotencyKey(ctx context.Context, key string, res *model.IdempotentResponse, ttl time.Duration) (bool, error)
+	GetIdempotencyKey(ctx context.Context, key string) (*model.IdempotentResponse, error)
+	SetIdempotencyKey(ctx context.Context, key string, res *model.IdempotentResponse, ttl time.Duration) error
+	DeleteIdempotencyKey(ctx context.Context, key string) error
+	PublishClick(ctx context.Context, urlID uuid.UUID, event *model.ClickEvent) error
+	RecentClicks(ctx context.Context, urlID uuid.UUID) ([]*model.ClickEvent, error)
+	SubscribeClicks(ctx context.Context, urlID uuid.UUID) (<-chan *model.ClickEvent, error)
 }
 
+// Recent clicks are kept so live stats clients can catch up after
+// reconnecting.
+const (
+	recentClicksLimit = 100
+	recentClicksTTL   = time.Hour
+)
+
 type cacheRepository struct {
 	client *redis.Client
 }
@@ -26,13 +43,23 @@ func NewClientRepository(client *redis.Client) CacheRepository {
 	return &cacheRepository{client: client}
 }
 
-func (r *cacheRepository) SetURL(ctx context.Context, shortCode string, url *model.URL, ttl time.Duration) error {
+// urlKey namespaces a short code by its domain. Codes on the default domain
+// keep their original unprefixed key.
+func urlKey(domain, shortCode string) string {
+	if domain == "" {
+		return fmt.Sprintf("url:%s", shortCode)
+	}
+
+	return fmt.Sprintf("url:%s/%s", domain, shortCode)
+}
+
+func (r *cacheRepository) SetURL(ctx context.Context, domain, shortCode string, url *model.URL, ttl time.Duration) error {
 	data, err := json.Marshal(url)
 	if err != nil {
 		return fmt.Errorf("failed to marshal URL: %w", err)
 	}
 
-	key := fmt.Sprintf("url:%s", shortCode)
+	key := urlKey(domain, shortCode)
 	err = r.client.Set(ctx, key, data, ttl).Err()
 	if err != nil {
 		return fmt.Errorf("failed to set URL in cache: %w", err)
@@ -41,8 +68,8 @@ func (r *cacheRepository) SetURL(ctx context.Context, shortCode string, url *mod
 	return nil
 }
 
-func (r *cacheRepository) GetURL(ctx context.Context, shortCode string) (*model.URL, error) {
-	key := fmt.Sprintf("url:%s", shortCode)
+func (r *cacheRepository) GetURL(ctx context.Context, domain, shortCode string) (*model.URL, error) {
+	key := urlKey(domain, shortCode)
 	data, err := r.client.Get(ctx, key).Bytes()
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
@@ -59,8 +86,8 @@ func (r *cacheRepository) GetURL(ctx context.Context, shortCode string) (*model.
 	return &url, nil
 }
 
-func (r *cacheRepository) DeleteURL(ctx context.Context, shortCode string) error {
-	key := fmt.Sprintf("url:%s", shortCode)
+func (r *cacheRepository) DeleteURL(ctx context.Context, domain, shortCode string) error {
+	key := urlKey(domain, shortCode)
 	err := r.client.Del(ctx, key).Err()
 	if err != nil {
 		return fmt.Errorf("failed to delete URL from cache: %w", err)
@@ -71,3 +98,168 @@ func (r *cacheRepository) DeleteURL(ctx context.Context, shortCode string) error
 func (r *cacheRepository) IncrementClicks(ctx context.Context, shortCode string) error {
 	return nil
 }
+
+func (r *cacheRepository) SetQRCode(ctx context.Context, key string, image []byte, ttl time.Duration) error {
+	err := r.client.Set(ctx, fmt.Sprintf("qr:%s", key), image, ttl).Err()
+	if err != nil {
+		return fmt.Errorf("failed to set QR code in cache: %w", err)
+	}
+
+	return nil
+}
+
+func (r *cacheRepository) GetQRCode(ctx context.Context, key string) ([]byte, error) {
+	data, err := r.client.Get(ctx, fmt.Sprintf("qr:%s", key)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get QR code from cache: %w", err)
+	}
+
+	return data, nil
+}
+
+// ReserveIdempotencyKey stores res under key unless the key is already taken,
+// reporting whether it was stored.
+func (r *cacheRepository) ReserveIdempotencyKey(ctx context.Context, key string, res *model.IdempotentResponse, ttl time.Duration) (bool, error) {
+	data, err := json.Marshal(res)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal idempotent response: %w", err)
+	}
+
+	ok, err := r.client.SetNX(ctx, fmt.Sprintf("idempotency:%s", key), data, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+
+	return ok, nil
+}
+
+func (r *cacheRepository) GetIdempotencyKey(ctx context.Context, key string) (*model.IdempotentResponse, error) {
+	data, err := r.client.Get(ctx, fmt.Sprintf("idempotency:%s", key)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get idempotency key from cache: %w", err)
+	}
+
+	var res model.IdempotentResponse
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal idempotent response: %w", err)
+	}
+
+	return &res, nil
+}
+
+func (r *cacheRepository) SetIdempotencyKey(ctx context.Context, key string, res *model.IdempotentResponse, ttl time.Duration) error {
+	data, err := json.Marshal(res)
+	if err != nil {
+		return fmt.Errorf("failed to marshal idempotent response: %w", err)
+	}
+
+	err = r.client.Set(ctx, fmt.Sprintf("idempotency:%s", key), data, ttl).Err()
+	if err != nil {
+		return fmt.Errorf("failed to set idempotency key in cache: %w", err)
+	}
+
+	return nil
+}
+
+func (r *cacheRepository) DeleteIdempotencyKey(ctx context.Context, key string) error {
+	err := r.client.Del(ctx, fmt.Sprintf("idempotency:%s", key)).Err()
+	if err != nil {
+		return fmt.Errorf("failed to delete idempotency key from cache: %w", err)
+	}
+
+	return nil
+}
+
+// PublishClick records a click in the link's recent clicks and publishes it
+// to subscribers on every instance.
+func (r *cacheRepository) PublishClick(ctx context.Context, urlID uuid.UUID, event *model.ClickEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal click event: %w", err)
+	}
+
+	key := fmt.Sprintf("clicks:recent:%s", urlID)
+
+	pipe := r.client.TxPipeline()
+	pipe.RPush(ctx, key, data)
+	pipe.LTrim(ctx, key, -recentClicksLimit, -1)
+	pipe.Expire(ctx, key, recentClicksTTL)
+	pipe.Publish(ctx, fmt.Sprintf("clicks:live:%s", urlID), data)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to publish click event: %w", err)
+	}
+
+	return nil
+}
+
+// RecentClicks returns the link's last clicks, oldest first.
+func (r *cacheRepository) RecentClicks(ctx context.Context, urlID uuid.UUID) ([]*model.ClickEvent, error) {
+	items, err := r.client.LRange(ctx, fmt.Sprintf("clicks:recent:%s", urlID), 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent clicks from cache: %w", err)
+	}
+
+	events := make([]*model.ClickEvent, 0, len(items))
+	for _, item := range items {
+		var event model.ClickEvent
+		if err := json.Unmarshal([]byte(item), &event); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal click event: %w", err)
+		}
+
+		events = append(events, &event)
+	}
+
+	return events, nil
+}
+
+// SubscribeClicks streams the link's clicks as they are published. The
+// subscription is active when it returns and the channel is closed once ctx
+// is done.
+func (r *cacheRepository) SubscribeClicks(ctx context.Context, urlID uuid.UUID) (<-chan *model.ClickEvent, error) {
+	pubsub := r.client.Subscribe(ctx, fmt.Sprintf("clicks:live:%s", urlID))
+
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, fmt.Errorf("failed to subscribe to clicks: %w", err)
+	}
+
+	events := make(chan *model.ClickEvent)
+
+	go func() {
+		defer close(events)
+		defer pubsub.Close()
+
+		messages := pubsub.Channel()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+
+				var event model.ClickEvent
+				if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+					continue
+				}
+
+				select {
+				case events <- &event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/ifaisalabid1/url-shortener/internal/model"
+	"github.com/lib/pq"
+)
+
+var (
+	ErrShortDomainNotFound = errors.New("short domain not found")
+	ErrShortDomainExists   = errors.New("short domain already exists")
+	ErrShortDomainInUse    = errors.New("short domain still has links")
+)
+
+type DomainRepository interface {
+	Create(ctx context.Context, domain *model.ShortDomain) error
+	List(ctx context.Context) ([]*model.ShortDomain, error)
+	UpdateFallbacks(ctx context.Context, host string, fallbacks *model.DomainFallbacks) error
+	Delete(ctx context.Context, host string) error
+}
+
+type domainRepository struct {
+	db *sql.DB
+}
+
+func NewDomainRepository(db *sql.DB) DomainRepository {
+	return &domainRepository{db: db}
+}
+
+func (r *domainRepository) Create(ctx context.Context, domain *model.ShortDomain) error {
+	query := "INSERT INTO short_domains (host, created_at, not_found_url, expired_url, disabled_url, root_url, catch_all_url) VALUES ($1, $2, $3, $4, $5, $6, $7)"
+
+	args := []any{domain.Host, domain.CreatedAt, domain.NotFoundURL, domain.ExpiredURL, domain.DisabledURL, domain.RootURL, domain.CatchAllURL}
+
+	_, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			if pqErr.Code == "23505" {
+				return ErrShortDomainExists
+			}
+		}
+
+		return fmt.Errorf("failed to create short domain: %w", err)
+	}
+
+	return nil
+}
+
+func (r *domainRepository) List(ctx context.Context) ([]*model.ShortDomain, error) {
+	query := `SELECT host, created_at, not_found_url, expired_url, disabled_url, root_url, catch_all_url
+			  FROM short_domains
+			  ORDER BY host`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list short domains: %w", err)
+	}
+	defer rows.Close()
+
+	var domains []*model.ShortDomain
+	for rows.Next() {
+		var domain model.ShortDomain
+		err := rows.Scan(
+			&domain.Host,
+			&domain.CreatedAt,
+			&domain.NotFoundURL,
+			&domain.ExpiredURL,
+			&domain.DisabledURL,
+			&domain.RootURL,
+			&domain.CatchAllURL,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan short domain: %w", err)
+		}
+
+		domains = append(domains, &domain)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list short domains: %w", err)
+	}
+
+	return domains, nil
+}
+
+func (r *domainRepository) UpdateFallbacks(ctx context.Context, host string, fallbacks *model.DomainFallbacks) error {
+	query := `UPDATE short_domains
+			  SET not_found_url = $2, expired_url = $3, disabled_url = $4, root_url = $5, catch_all_url = $6
+			  WHERE host = $1`
+
+	args := []any{host, fallbacks.NotFoundURL, fallbacks.ExpiredURL, fallbacks.DisabledURL, fallbacks.RootURL, fallbacks.CatchAllURL}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update short domain: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return ErrShortDomainNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a domain that no longer has any links, including trashed
+// ones, so that no link is stranded on a host that is no longer served.
+func (r *domainRepository) Delete(ctx context.Context, host string) error {
+	var inUse bool
+
+	err := r.db.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM urls WHERE domain = $1)", host).Scan(&inUse)
+	if err != nil {
+		return fmt.Errorf("failed to check short domain links: %w", err)
+	}
+
+	if inUse {
+		return ErrShortDomainInUse
+	}
+
+	result, err := r.db.ExecContext(ctx, "DELETE FROM short_domains WHERE host = $1", host)
+	if err != nil {
+		return fmt.Errorf("failed to delete short domain: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return ErrShortDomainNotFound
+	}
+
+	return nil
+}
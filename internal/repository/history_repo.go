@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ifaisalabid1/url-shortener/internal/model"
+	"github.com/lib/pq"
+)
+
+var ErrHistoryNotFound = errors.New("history entry not found")
+
+type HistoryRepository interface {
+	ListByURLID(ctx context.Context, urlID uuid.UUID) ([]*model.HistoryEntry, error)
+	ListByURLIDs(ctx context.Context, urlIDs []uuid.UUID) ([]*model.HistoryEntry, error)
+	Get(ctx context.Context, urlID uuid.UUID, version int) (*model.HistoryEntry, error)
+}
+
+type historyRepository struct {
+	db *sql.DB
+}
+
+func NewHistoryRepository(db *sql.DB) HistoryRepository {
+	return &historyRepository{db: db}
+}
+
+const historyColumns = "id, url_id, version, action, actor, actor_ip, old_value, new_value, created_at"
+
+func scanHistoryEntry(row rowScanner) (*model.HistoryEntry, error) {
+	var entry model.HistoryEntry
+
+	err := row.Scan(
+		&entry.ID,
+		&entry.URLID,
+		&entry.Version,
+		&entry.Action,
+		&entry.Actor,
+		&entry.ActorIP,
+		&entry.OldValue,
+		&entry.NewValue,
+		&entry.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrHistoryNotFound
+		}
+
+		return nil, fmt.Errorf("failed to get history entry: %w", err)
+	}
+
+	return &entry, nil
+}
+
+// insertHistory appends an entry inside the transaction that changed its
+// URL, assigning it the next version number. The change has already written
+// or locked the URL row, so concurrent changes to one URL wait for this
+// transaction and get consecutive versions.
+func insertHistory(ctx context.Context, tx *sql.Tx, entry *model.HistoryEntry) error {
+	query := `INSERT INTO url_history (id, url_id, version, action, actor, actor_ip, old_value, new_value, created_at)
+			  SELECT $1, $2, COALESCE(MAX(version), 0) + 1, $3, $4, $5, $6, $7, $8
+			  FROM url_history WHERE url_id = $2
+			  RETURNING version`
+
+	args := []any{entry.ID, entry.URLID, entry.Action, entry.Actor, entry.ActorIP, entry.OldValue, entry.NewValue, entry.CreatedAt}
+
+	if err := tx.QueryRowContext(ctx, query, args...).Scan(&entry.Version); err != nil {
+		return fmt.Errorf("failed to create history entry: %w", err)
+	}
+
+	return nil
+}
+
+func (r *historyRepository) ListByURLID(ctx context.Context, urlID uuid.UUID) ([]*model.HistoryEntry, error) {
+	query := "SELECT " + historyColumns + " FROM url_history WHERE url_id = $1 ORDER BY version"
+
+	return r.list(ctx, query, urlID)
+}
+
+// ListByURLIDs returns the history of several URLs in one query.
+func (r *historyRepository) ListByURLIDs(ctx context.Context, urlIDs []uuid.UUID) ([]*model.HistoryEntry, error) {
+	query := "SELECT " + historyColumns + " FROM url_history WHERE url_id = ANY($1::uuid[]) ORDER BY url_id, version"
+
+	return r.list(ctx, query, pq.Array(uuidStrings(urlIDs)))
+}
+
+func (r *historyRepository) list(ctx context.Context, query string, args ...any) ([]*model.HistoryEntry, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list history: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*model.HistoryEntry
+	for rows.Next() {
+		entry, err := scanHistoryEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list history: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (r *historyRepository) Get(ctx context.Context, urlID uuid.UUID, version int) (*model.HistoryEntry, error) {
+	query := "SELECT " + historyColumns + " FROM url_history WHERE url_id = $1 AND version = $2"
+
+	return scanHistoryEntry(r.db.QueryRowContext(ctx, query, urlID, version))
+}
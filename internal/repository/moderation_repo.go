@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ifaisalabid1/url-shortener/internal/model"
+	"github.com/lib/pq"
+)
+
+var (
+	ErrReportNotFound = errors.New("report not found")
+	ErrDomainNotFound = errors.New("domain not found")
+)
+
+type ModerationRepository interface {
+	CreateReport(ctx context.Context, report *model.Report) error
+	GetReport(ctx context.Context, id uuid.UUID) (*model.Report, error)
+	ListReports(ctx context.Context, status string) ([]*model.Report, error)
+	UpdateReport(ctx context.Context, report *model.Report) error
+	BanDomain(ctx context.Context, domain *model.BannedDomain) error
+	UnbanDomain(ctx context.Context, domain string) error
+	ListBannedDomains(ctx context.Context) ([]*model.BannedDomain, error)
+	IsDomainBanned(ctx context.Context, domains []string) (bool, error)
+}
+
+type moderationRepository struct {
+	db *sql.DB
+}
+
+func NewModerationRepository(db *sql.DB) ModerationRepository {
+	return &moderationRepository{db: db}
+}
+
+const reportColumns = "id, url_id, short_code, reason, details, reporter_ip, status, created_at, resolved_at"
+
+func scanReport(row rowScanner) (*model.Report, error) {
+	var report model.Report
+
+	err := row.Scan(
+		&report.ID,
+		&report.URLID,
+		&report.ShortCode,
+		&report.Reason,
+		&report.Details,
+		&report.ReporterIP,
+		&report.Status,
+		&report.CreatedAt,
+		&report.ResolvedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrReportNotFound
+		}
+
+		return nil, fmt.Errorf("failed to get report: %w", err)
+	}
+
+	return &report, nil
+}
+
+func (r *moderationRepository) CreateReport(ctx context.Context, report *model.Report) error {
+	query := "INSERT INTO reports (" + reportColumns + ") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)"
+
+	args := []any{report.ID, report.URLID, report.ShortCode, report.Reason, report.Details, report.ReporterIP, report.Status, report.CreatedAt, report.ResolvedAt}
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to create report: %w", err)
+	}
+
+	return nil
+}
+
+func (r *moderationRepository) GetReport(ctx context.Context, id uuid.UUID) (*model.Report, error) {
+	query := "SELECT " + reportColumns + " FROM reports WHERE id = $1"
+
+	return scanReport(r.db.QueryRowContext(ctx, query, id))
+}
+
+func (r *moderationRepository) ListReports(ctx context.Context, status string) ([]*model.Report, error) {
+	query := `SELECT ` + reportColumns + `
+			  FROM reports
+			  WHERE $1 = '' OR status = $1
+			  ORDER BY created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reports: %w", err)
+	}
+	defer rows.Close()
+
+	var reports []*model.Report
+	for rows.Next() {
+		report, err := scanReport(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		reports = append(reports, report)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list reports: %w", err)
+	}
+
+	return reports, nil
+}
+
+func (r *moderationRepository) UpdateReport(ctx context.Context, report *model.Report) error {
+	query := "UPDATE reports SET status = $2, resolved_at = $3 WHERE id = $1"
+
+	result, err := r.db.ExecContext(ctx, query, report.ID, report.Status, report.ResolvedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update report: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return ErrReportNotFound
+	}
+
+	return nil
+}
+
+func (r *moderationRepository) BanDomain(ctx context.Context, domain *model.BannedDomain) error {
+	query := `INSERT INTO banned_domains (domain, reason, created_at) VALUES ($1, $2, $3)
+			  ON CONFLICT (domain) DO UPDATE SET reason = EXCLUDED.reason`
+
+	if _, err := r.db.ExecContext(ctx, query, domain.Domain, domain.Reason, domain.CreatedAt); err != nil {
+		return fmt.Errorf("failed to ban domain: %w", err)
+	}
+
+	return nil
+}
+
+func (r *moderationRepository) UnbanDomain(ctx context.Context, domain string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM banned_domains WHERE domain = $1", domain)
+	if err != nil {
+		return fmt.Errorf("failed to unban domain: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return ErrDomainNotFound
+	}
+
+	return nil
+}
+
+func (r *moderationRepository) ListBannedDomains(ctx context.Context) ([]*model.BannedDomain, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT domain, reason, created_at FROM banned_domains ORDER BY domain")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list banned domains: %w", err)
+	}
+	defer rows.Close()
+
+	var domains []*model.BannedDomain
+	for rows.Next() {
+		var domain model.BannedDomain
+		if err := rows.Scan(&domain.Domain, &domain.Reason, &domain.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan banned domain: %w", err)
+		}
+
+		domains = append(domains, &domain)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list banned domains: %w", err)
+	}
+
+	return domains, nil
+}
+
+func (r *moderationRepository) IsDomainBanned(ctx context.Context, domains []string) (bool, error) {
+	var banned bool
+
+	query := "SELECT EXISTS (SELECT 1 FROM banned_domains WHERE domain = ANY($1))"
+
+	if err := r.db.QueryRowContext(ctx, query, pq.Array(domains)).Scan(&banned); err != nil {
+		return false, fmt.Errorf("failed to check banned domains: %w", err)
+	}
+
+	return banned, nil
+}
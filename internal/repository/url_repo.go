@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/ifaisalabid1/url-shortener/internal/model"
@@ -17,11 +18,31 @@ var (
 )
 
 type URLRepository interface {
-	Create(ctx context.Context, url *model.URL) error
-	GetByShortCode(ctx context.Context, shortCode string) (*model.URL, error)
+	Create(ctx context.Context, url *model.URL, history *model.HistoryEntry) error
+	GetByShortCode(ctx context.Context, domain, shortCode string) (*model.URL, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*model.URL, error)
-	IncrementClicks(ctx context.Context, shortCode string) error
-	DeleteExpired(ctx context.Context) error
+	GetByURLHash(ctx context.Context, domain, creator, hash string) (*model.URL, error)
+	Update(ctx context.Context, url *model.URL, history *model.HistoryEntry) error
+	SetStatus(ctx context.Context, id uuid.UUID, status, reason string) error
+	BanByDomain(ctx context.Context, domain, reason string) ([]model.ShortLink, error)
+	IncrementClicks(ctx context.Context, domain, shortCode string) (int64, error)
+	ListDueForHealthCheck(ctx context.Context, checkedBefore time.Time, limit int) ([]*model.URL, error)
+	UpdateHealth(ctx context.Context, id uuid.UUID, health *model.LinkHealth) error
+	ListBroken(ctx context.Context, limit, offset int) ([]*model.URL, error)
+	ListExpired(ctx context.Context, limit int) ([]*model.URL, error)
+	DeleteExpired(ctx context.Context, id uuid.UUID, history *model.HistoryEntry) error
+	SoftDelete(ctx context.Context, id uuid.UUID, history *model.HistoryEntry) error
+	Restore(ctx context.Context, id uuid.UUID, history *model.HistoryEntry) error
+	Purge(ctx context.Context, id uuid.UUID, history *model.HistoryEntry) error
+	GetDeletedByShortCode(ctx context.Context, domain, shortCode string) (*model.URL, error)
+	GetExpiredByShortCode(ctx context.Context, domain, shortCode string) (*model.URL, error)
+	ListDeleted(ctx context.Context, limit, offset int) ([]*model.URL, error)
+	ListDeletedBefore(ctx context.Context, deletedBefore time.Time, limit int) ([]*model.URL, error)
+	List(ctx context.Context, filter model.URLFilter) ([]*model.URL, error)
+	TagStats(ctx context.Context) ([]*model.TagStats, error)
+	ClaimNewlyExpired(ctx context.Context, limit int) ([]*model.URL, error)
+	ListStaleHashes(ctx context.Context, limit int) ([]*model.URL, error)
+	UpdateHash(ctx context.Context, id uuid.UUID, normalizedURL, hash string) error
 }
 
 type urlRepository struct {
@@ -32,12 +53,23 @@ func NewURLRepository(db *sql.DB) URLRepository {
 	return &urlRepository{db: db}
 }
 
-func (r *urlRepository) Create(ctx context.Context, url *model.URL) error {
-	query := "INSERT INTO urls (id, short_code, original_url, created_at, updated_at, clicks, expires_at) VALUES ($1, $2, $3, $4, $5, $6, $7)"
+// Create inserts a URL and its history entry in one transaction.
+func (r *urlRepository) Create(ctx context.Context, url *model.URL, history *model.HistoryEntry) error {
+	query := "INSERT INTO urls (id, short_code, original_url, created_at, updated_at, clicks, expires_at, language_targets, open_graph, normalized_url, url_hash, status, interstitial, creator_trust, creator, folder, domain) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)"
 
-	args := []any{url.ID, url.ShortCode, url.OriginalURL, url.CreatedAt, url.UpdatedAt, url.Clicks, url.ExpiresAt}
+	args := []any{url.ID, url.ShortCode, url.OriginalURL, url.CreatedAt, url.UpdatedAt, url.Clicks, url.ExpiresAt, url.LanguageTargets, url.OpenGraph, url.NormalizedURL, url.URLHash, url.Status, url.Interstitial, url.CreatorTrust, url.Creator, url.Folder, url.Domain}
 
-	_, err := r.db.ExecContext(ctx, query, args...)
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := reserveShortCode(ctx, tx, url.Domain, url.ShortCode); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
 	if err != nil {
 		var pqErr *pq.Error
 		if errors.As(err, &pqErr) {
@@ -49,18 +81,76 @@ func (r *urlRepository) Create(ctx context.Context, url *model.URL) error {
 		return fmt.Errorf("failed to create url: %w", err)
 	}
 
+	if err := replaceTags(ctx, tx, url.ID, url.Tags); err != nil {
+		return err
+	}
+
+	if err := insertHistory(ctx, tx, history); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to create url: %w", err)
+	}
+
 	return nil
 
 }
 
-func (r *urlRepository) GetByShortCode(ctx context.Context, shortCode string) (*model.URL, error) {
-	var url model.URL
+// reserveShortCode locks a short code on a domain for the rest of the
+// transaction and fails with ErrDuplicateCode if a link or alias already
+// uses it. Links and aliases share one namespace in separate tables, so
+// their unique indexes cannot catch a clash between the two.
+func reserveShortCode(ctx context.Context, tx *sql.Tx, domain, shortCode string) error {
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext($1 || '/' || $2))", domain, shortCode); err != nil {
+		return fmt.Errorf("failed to lock short code: %w", err)
+	}
 
-	query := `SELECT id, short_code, original_url, created_at, updated_at, clicks, expires_at
-			  FROM urls
-			  WHERE short_code = $1 AND (expires_at IS NULL OR expires_at > NOW())`
+	query := `SELECT EXISTS (SELECT 1 FROM urls WHERE domain = $1 AND short_code = $2)
+			  OR EXISTS (SELECT 1 FROM url_aliases WHERE domain = $1 AND short_code = $2)`
+
+	var taken bool
+	if err := tx.QueryRowContext(ctx, query, domain, shortCode).Scan(&taken); err != nil {
+		return fmt.Errorf("failed to check short code: %w", err)
+	}
+
+	if taken {
+		return ErrDuplicateCode
+	}
+
+	return nil
+}
+
+// replaceTags sets the tags of a URL to exactly the given list.
+func replaceTags(ctx context.Context, tx *sql.Tx, id uuid.UUID, tags []string) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM url_tags WHERE url_id = $1", id); err != nil {
+		return fmt.Errorf("failed to clear url tags: %w", err)
+	}
 
-	err := r.db.QueryRowContext(ctx, query, url.ShortCode).Scan(
+	if len(tags) == 0 {
+		return nil
+	}
+
+	query := "INSERT INTO url_tags (url_id, tag) SELECT $1, unnest($2::text[]) ON CONFLICT DO NOTHING"
+
+	if _, err := tx.ExecContext(ctx, query, id, pq.Array(tags)); err != nil {
+		return fmt.Errorf("failed to set url tags: %w", err)
+	}
+
+	return nil
+}
+
+const urlColumns = "id, short_code, original_url, created_at, updated_at, clicks, expires_at, language_targets, open_graph, normalized_url, url_hash, status, status_reason, interstitial, creator_trust, creator, health, deleted_at, domain, folder, " +
+	"ARRAY(SELECT tag FROM url_tags WHERE url_tags.url_id = urls.id ORDER BY tag) AS tags"
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanURL(row rowScanner) (*model.URL, error) {
+	var url model.URL
+
+	err := row.Scan(
 		&url.ID,
 		&url.ShortCode,
 		&url.OriginalURL,
@@ -68,6 +158,20 @@ func (r *urlRepository) GetByShortCode(ctx context.Context, shortCode string) (*
 		&url.UpdatedAt,
 		&url.Clicks,
 		&url.ExpiresAt,
+		&url.LanguageTargets,
+		&url.OpenGraph,
+		&url.NormalizedURL,
+		&url.URLHash,
+		&url.Status,
+		&url.StatusReason,
+		&url.Interstitial,
+		&url.CreatorTrust,
+		&url.Creator,
+		&url.Health,
+		&url.DeletedAt,
+		&url.Domain,
+		&url.Folder,
+		pq.Array(&url.Tags),
 	)
 
 	if err != nil {
@@ -81,40 +185,299 @@ func (r *urlRepository) GetByShortCode(ctx context.Context, shortCode string) (*
 	return &url, nil
 }
 
+func (r *urlRepository) GetByShortCode(ctx context.Context, domain, shortCode string) (*model.URL, error) {
+	query := `SELECT ` + urlColumns + `
+			  FROM urls
+			  WHERE domain = $1
+			  AND (short_code = $2 OR id = (SELECT url_id FROM url_aliases WHERE domain = $1 AND short_code = $2))
+			  AND deleted_at IS NULL
+			  AND (expires_at IS NULL OR expires_at > NOW())`
+
+	return scanURL(r.db.QueryRowContext(ctx, query, domain, shortCode))
+}
+
 func (r *urlRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.URL, error) {
-	var url model.URL
+	query := `SELECT ` + urlColumns + `
+			  FROM urls
+			  WHERE id = $1 AND deleted_at IS NULL`
+
+	return scanURL(r.db.QueryRowContext(ctx, query, id))
+}
 
-	query := `SELECT id, short_code, original_url, created_at, updated_at, clicks, expires_at
+// GetByURLHash returns the newest live link to a destination made by the
+// given creator.
+func (r *urlRepository) GetByURLHash(ctx context.Context, domain, creator, hash string) (*model.URL, error) {
+	query := `SELECT ` + urlColumns + `
 			  FROM urls
+			  WHERE domain = $1 AND creator = $2 AND url_hash = $3 AND NOT url_hash_stale
+			  AND status = 'active' AND deleted_at IS NULL
+			  AND (expires_at IS NULL OR expires_at > NOW())
+			  ORDER BY created_at DESC
+			  LIMIT 1`
+
+	return scanURL(r.db.QueryRowContext(ctx, query, domain, creator, hash))
+}
+
+// Update saves a URL's editable fields and its history entry in one
+// transaction.
+func (r *urlRepository) Update(ctx context.Context, url *model.URL, history *model.HistoryEntry) error {
+	// A new expiry time is reported again once it passes.
+	query := `UPDATE urls
+			  SET original_url = $2, normalized_url = $3, url_hash = $4, url_hash_stale = FALSE, expires_at = $5, language_targets = $6, open_graph = $7, interstitial = $8, folder = $9,
+			  expiry_notified_at = CASE WHEN expires_at IS DISTINCT FROM $5 THEN NULL ELSE expiry_notified_at END
 			  WHERE id = $1`
 
-	err := r.db.QueryRowContext(ctx, query, url.ID).Scan(
-		&url.ID,
-		&url.ShortCode,
-		&url.OriginalURL,
-		&url.CreatedAt,
-		&url.UpdatedAt,
-		&url.Clicks,
-		&url.ExpiresAt,
-	)
+	args := []any{url.ID, url.OriginalURL, url.NormalizedURL, url.URLHash, url.ExpiresAt, url.LanguageTargets, url.OpenGraph, url.Interstitial, url.Folder}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update url: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return ErrURLNotFound
+	}
+
+	if err := replaceTags(ctx, tx, url.ID, url.Tags); err != nil {
+		return err
+	}
+
+	if err := insertHistory(ctx, tx, history); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to update url: %w", err)
+	}
+
+	return nil
+}
+
+func (r *urlRepository) SetStatus(ctx context.Context, id uuid.UUID, status, reason string) error {
+	query := "UPDATE urls SET status = $2, status_reason = $3 WHERE id = $1"
+
+	result, err := r.db.ExecContext(ctx, query, id, status, reason)
+	if err != nil {
+		return fmt.Errorf("failed to set url status: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return ErrURLNotFound
+	}
+
+	return nil
+}
 
+// BanByDomain bans every link whose destination is the domain or one of its
+// subdomains, returning the short codes and aliases that were banned.
+func (r *urlRepository) BanByDomain(ctx context.Context, domain, reason string) ([]model.ShortLink, error) {
+	query := `WITH hosts AS (
+				SELECT id, substring(normalized_url from '^[a-z][a-z0-9+.-]*://(?:[^@/]*@)?([^/:?#]+)') AS host
+				FROM urls
+				WHERE status <> 'banned'
+			  ), banned AS (
+				UPDATE urls SET status = 'banned', status_reason = $2
+				FROM hosts
+				WHERE hosts.id = urls.id AND (hosts.host = $1 OR right(hosts.host, length($1) + 1) = '.' || $1)
+				RETURNING urls.id, urls.domain, urls.short_code
+			  )
+			  SELECT domain, short_code FROM banned
+			  UNION ALL
+			  SELECT url_aliases.domain, url_aliases.short_code FROM url_aliases JOIN banned ON url_aliases.url_id = banned.id`
+
+	rows, err := r.db.QueryContext(ctx, query, domain, reason)
 	if err != nil {
+		return nil, fmt.Errorf("failed to ban urls by domain: %w", err)
+	}
+	defer rows.Close()
+
+	var links []model.ShortLink
+	for rows.Next() {
+		var link model.ShortLink
+		if err := rows.Scan(&link.Domain, &link.ShortCode); err != nil {
+			return nil, fmt.Errorf("failed to scan short code: %w", err)
+		}
+
+		links = append(links, link)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to ban urls by domain: %w", err)
+	}
+
+	return links, nil
+}
+
+// IncrementClicks counts a click and returns the canonical URL's new total.
+func (r *urlRepository) IncrementClicks(ctx context.Context, domain, shortCode string) (int64, error) {
+	// Alias clicks are counted on the alias and rolled up into the canonical URL.
+	query := `WITH alias AS (
+				UPDATE url_aliases SET clicks = clicks + 1 WHERE domain = $1 AND short_code = $2 RETURNING url_id
+			  )
+			  UPDATE urls SET clicks = clicks + 1
+			  WHERE (domain = $1 AND short_code = $2) OR id IN (SELECT url_id FROM alias)
+			  RETURNING clicks`
+
+	var clicks int64
+	if err := r.db.QueryRowContext(ctx, query, domain, shortCode).Scan(&clicks); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, ErrURLNotFound
+			return 0, ErrURLNotFound
 		}
 
-		return nil, fmt.Errorf("failed to get url: %w", err)
+		return 0, fmt.Errorf("failed to increment clicks: %w", err)
 	}
 
-	return &url, nil
+	return clicks, nil
+}
+
+func (r *urlRepository) ListDueForHealthCheck(ctx context.Context, checkedBefore time.Time, limit int) ([]*model.URL, error) {
+	query := `SELECT ` + urlColumns + `
+			  FROM urls
+			  WHERE status = 'active' AND deleted_at IS NULL
+			  AND (expires_at IS NULL OR expires_at > NOW())
+			  AND (health_checked_at IS NULL OR health_checked_at < $1)
+			  ORDER BY health_checked_at NULLS FIRST
+			  LIMIT $2`
+
+	return r.queryURLs(ctx, query, checkedBefore, limit)
+}
+
+func (r *urlRepository) UpdateHealth(ctx context.Context, id uuid.UUID, health *model.LinkHealth) error {
+	query := "UPDATE urls SET health = $2, health_checked_at = $3 WHERE id = $1"
+
+	if _, err := r.db.ExecContext(ctx, query, id, health, health.CheckedAt); err != nil {
+		return fmt.Errorf("failed to update url health: %w", err)
+	}
+
+	return nil
 }
 
-func (r *urlRepository) IncrementClicks(ctx context.Context, shortCode string) error {
-	query := "UPDATE urls SET clicks = clicks + 1 WHERE short_code = $1"
+func (r *urlRepository) ListBroken(ctx context.Context, limit, offset int) ([]*model.URL, error) {
+	query := `SELECT ` + urlColumns + `
+			  FROM urls
+			  WHERE (health->>'broken')::boolean AND deleted_at IS NULL
+			  ORDER BY health_checked_at DESC
+			  LIMIT $1 OFFSET $2`
+
+	return r.queryURLs(ctx, query, limit, offset)
+}
 
-	result, err := r.db.ExecContext(ctx, query, shortCode)
+func (r *urlRepository) queryURLs(ctx context.Context, query string, args ...any) ([]*model.URL, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return fmt.Errorf("failed to increment clicks: %w", err)
+		return nil, fmt.Errorf("failed to list urls: %w", err)
+	}
+	defer rows.Close()
+
+	var urls []*model.URL
+	for rows.Next() {
+		url, err := scanURL(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		urls = append(urls, url)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list urls: %w", err)
+	}
+
+	return urls, nil
+}
+
+// ClaimNewlyExpired marks up to limit links that have expired since the last
+// call as notified and returns them, so each expiry is reported once even
+// with several instances polling.
+func (r *urlRepository) ClaimNewlyExpired(ctx context.Context, limit int) ([]*model.URL, error) {
+	query := `UPDATE urls SET expiry_notified_at = NOW()
+			  WHERE id IN (
+				SELECT id FROM urls
+				WHERE expires_at <= NOW() AND expiry_notified_at IS NULL AND deleted_at IS NULL
+				ORDER BY expires_at
+				LIMIT $1
+				FOR UPDATE SKIP LOCKED
+			  )
+			  RETURNING ` + urlColumns
+
+	return r.queryURLs(ctx, query, limit)
+}
+
+// ListStaleHashes returns up to limit links whose hash predates URL
+// normalization.
+func (r *urlRepository) ListStaleHashes(ctx context.Context, limit int) ([]*model.URL, error) {
+	query := `SELECT ` + urlColumns + `
+			  FROM urls
+			  WHERE url_hash_stale
+			  LIMIT $1`
+
+	return r.queryURLs(ctx, query, limit)
+}
+
+func (r *urlRepository) UpdateHash(ctx context.Context, id uuid.UUID, normalizedURL, hash string) error {
+	query := "UPDATE urls SET normalized_url = $2, url_hash = $3, url_hash_stale = FALSE WHERE id = $1"
+
+	if _, err := r.db.ExecContext(ctx, query, id, normalizedURL, hash); err != nil {
+		return fmt.Errorf("failed to update url hash: %w", err)
+	}
+
+	return nil
+}
+
+func (r *urlRepository) ListExpired(ctx context.Context, limit int) ([]*model.URL, error) {
+	query := `SELECT ` + urlColumns + `
+			  FROM urls
+			  WHERE expires_at <= NOW()
+			  ORDER BY expires_at
+			  LIMIT $1`
+
+	return r.queryURLs(ctx, query, limit)
+}
+
+func (r *urlRepository) DeleteExpired(ctx context.Context, id uuid.UUID, history *model.HistoryEntry) error {
+	return r.execWithHistory(ctx, "DELETE FROM urls WHERE id = $1 AND expires_at <= NOW()", id, history)
+}
+
+func (r *urlRepository) SoftDelete(ctx context.Context, id uuid.UUID, history *model.HistoryEntry) error {
+	return r.execWithHistory(ctx, "UPDATE urls SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL", id, history)
+}
+
+func (r *urlRepository) Restore(ctx context.Context, id uuid.UUID, history *model.HistoryEntry) error {
+	return r.execWithHistory(ctx, "UPDATE urls SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL", id, history)
+}
+
+func (r *urlRepository) Purge(ctx context.Context, id uuid.UUID, history *model.HistoryEntry) error {
+	return r.execWithHistory(ctx, "DELETE FROM urls WHERE id = $1 AND deleted_at IS NOT NULL", id, history)
+}
+
+// execWithHistory runs a statement that must change the URL with the given
+// id and records its history entry in the same transaction.
+func (r *urlRepository) execWithHistory(ctx context.Context, query string, id uuid.UUID, history *model.HistoryEntry) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to update url: %w", err)
 	}
 
 	rows, err := result.RowsAffected()
@@ -126,16 +489,101 @@ func (r *urlRepository) IncrementClicks(ctx context.Context, shortCode string) e
 		return ErrURLNotFound
 	}
 
+	if err := insertHistory(ctx, tx, history); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to update url: %w", err)
+	}
+
 	return nil
 }
 
-func (r *urlRepository) DeleteExpired(ctx context.Context) error {
-	query := "DELETE FROM urls WHERE expires_at <= NOW()"
+func (r *urlRepository) GetDeletedByShortCode(ctx context.Context, domain, shortCode string) (*model.URL, error) {
+	query := `SELECT ` + urlColumns + `
+			  FROM urls
+			  WHERE domain = $1 AND short_code = $2 AND deleted_at IS NOT NULL`
+
+	return scanURL(r.db.QueryRowContext(ctx, query, domain, shortCode))
+}
 
-	_, err := r.db.ExecContext(ctx, query)
+// GetExpiredByShortCode finds a link that has expired but has not yet been
+// removed by the cleanup job.
+func (r *urlRepository) GetExpiredByShortCode(ctx context.Context, domain, shortCode string) (*model.URL, error) {
+	query := `SELECT ` + urlColumns + `
+			  FROM urls
+			  WHERE domain = $1
+			  AND (short_code = $2 OR id = (SELECT url_id FROM url_aliases WHERE domain = $1 AND short_code = $2))
+			  AND deleted_at IS NULL
+			  AND expires_at <= NOW()`
+
+	return scanURL(r.db.QueryRowContext(ctx, query, domain, shortCode))
+}
+
+func (r *urlRepository) ListDeleted(ctx context.Context, limit, offset int) ([]*model.URL, error) {
+	query := `SELECT ` + urlColumns + `
+			  FROM urls
+			  WHERE deleted_at IS NOT NULL
+			  ORDER BY deleted_at DESC
+			  LIMIT $1 OFFSET $2`
+
+	return r.queryURLs(ctx, query, limit, offset)
+}
+
+// ListDeletedBefore returns up to limit links moved to the trash before
+// deletedBefore, oldest first.
+func (r *urlRepository) ListDeletedBefore(ctx context.Context, deletedBefore time.Time, limit int) ([]*model.URL, error) {
+	query := `SELECT ` + urlColumns + `
+			  FROM urls
+			  WHERE deleted_at <= $1
+			  ORDER BY deleted_at
+			  LIMIT $2`
+
+	return r.queryURLs(ctx, query, deletedBefore, limit)
+}
+
+func (r *urlRepository) List(ctx context.Context, filter model.URLFilter) ([]*model.URL, error) {
+	query := `SELECT ` + urlColumns + `
+			  FROM urls
+			  WHERE deleted_at IS NULL
+			  AND ($1::text = '' OR id IN (SELECT url_id FROM url_tags WHERE tag = $1))
+			  AND ($2::text = '' OR folder = $2)
+			  ORDER BY created_at DESC
+			  LIMIT $3 OFFSET $4`
+
+	return r.queryURLs(ctx, query, filter.Tag, filter.Folder, filter.Limit, filter.Offset)
+}
+
+// TagStats returns every tag in use with the number of links carrying it and
+// their combined clicks, most clicked first.
+func (r *urlRepository) TagStats(ctx context.Context) ([]*model.TagStats, error) {
+	query := `SELECT url_tags.tag, COUNT(*), COALESCE(SUM(urls.clicks), 0)
+			  FROM url_tags
+			  JOIN urls ON urls.id = url_tags.url_id
+			  WHERE urls.deleted_at IS NULL
+			  GROUP BY url_tags.tag
+			  ORDER BY 3 DESC, url_tags.tag`
+
+	rows, err := r.db.QueryContext(ctx, query)
 	if err != nil {
-		return fmt.Errorf("failed to delete url: %w", err)
+		return nil, fmt.Errorf("failed to get tag stats: %w", err)
 	}
+	defer rows.Close()
 
-	return nil
+	var stats []*model.TagStats
+	for rows.Next() {
+		var s model.TagStats
+		if err := rows.Scan(&s.Tag, &s.Links, &s.Clicks); err != nil {
+			return nil, fmt.Errorf("failed to scan tag stats: %w", err)
+		}
+
+		stats = append(stats, &s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get tag stats: %w", err)
+	}
+
+	return stats, nil
 }
@@ -0,0 +1,303 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ifaisalabid1/url-shortener/internal/model"
+	"github.com/lib/pq"
+)
+
+var (
+	ErrWebhookNotFound  = errors.New("webhook subscription not found")
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+)
+
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, sub *model.WebhookSubscription) error
+	GetSubscription(ctx context.Context, id uuid.UUID) (*model.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context, owner string) ([]*model.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id uuid.UUID, owner string) error
+	Enqueue(ctx context.Context, owner string, eventID uuid.UUID, event string, payload []byte) (int64, error)
+	ClaimDue(ctx context.Context, lease time.Duration, limit int) ([]*model.WebhookDelivery, error)
+	RecordAttempt(ctx context.Context, delivery *model.WebhookDelivery, attempt *model.WebhookAttempt) error
+	ListDeliveries(ctx context.Context, status string, limit, offset int) ([]*model.WebhookDelivery, error)
+	ListAttempts(ctx context.Context, deliveryID uuid.UUID) ([]*model.WebhookAttempt, error)
+	Requeue(ctx context.Context, id uuid.UUID) error
+}
+
+type webhookRepository struct {
+	db *sql.DB
+}
+
+func NewWebhookRepository(db *sql.DB) WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+const subscriptionColumns = "id, url, secret, events, active, owner, created_at"
+
+const deliveryColumns = "id, subscription_id, event_id, event, payload, status, attempts, next_attempt_at, last_status_code, last_error, created_at, delivered_at"
+
+func scanSubscription(row rowScanner) (*model.WebhookSubscription, error) {
+	var sub model.WebhookSubscription
+
+	err := row.Scan(&sub.ID, &sub.URL, &sub.Secret, pq.Array(&sub.Events), &sub.Active, &sub.Owner, &sub.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrWebhookNotFound
+		}
+
+		return nil, fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+
+	return &sub, nil
+}
+
+func scanDelivery(row rowScanner) (*model.WebhookDelivery, error) {
+	var delivery model.WebhookDelivery
+
+	err := row.Scan(
+		&delivery.ID,
+		&delivery.SubscriptionID,
+		&delivery.EventID,
+		&delivery.Event,
+		&delivery.Payload,
+		&delivery.Status,
+		&delivery.Attempts,
+		&delivery.NextAttemptAt,
+		&delivery.LastStatusCode,
+		&delivery.LastError,
+		&delivery.CreatedAt,
+		&delivery.DeliveredAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrDeliveryNotFound
+		}
+
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	return &delivery, nil
+}
+
+func (r *webhookRepository) CreateSubscription(ctx context.Context, sub *model.WebhookSubscription) error {
+	query := "INSERT INTO webhook_subscriptions (" + subscriptionColumns + ") VALUES ($1, $2, $3, $4, $5, $6, $7)"
+
+	_, err := r.db.ExecContext(ctx, query, sub.ID, sub.URL, sub.Secret, pq.Array(sub.Events), sub.Active, sub.Owner, sub.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+
+	return nil
+}
+
+func (r *webhookRepository) GetSubscription(ctx context.Context, id uuid.UUID) (*model.WebhookSubscription, error) {
+	query := "SELECT " + subscriptionColumns + " FROM webhook_subscriptions WHERE id = $1"
+
+	return scanSubscription(r.db.QueryRowContext(ctx, query, id))
+}
+
+func (r *webhookRepository) ListSubscriptions(ctx context.Context, owner string) ([]*model.WebhookSubscription, error) {
+	query := "SELECT " + subscriptionColumns + " FROM webhook_subscriptions WHERE owner = $1 ORDER BY created_at"
+
+	rows, err := r.db.QueryContext(ctx, query, owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	var subs []*model.WebhookSubscription
+	for rows.Next() {
+		sub, err := scanSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		subs = append(subs, sub)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+
+	return subs, nil
+}
+
+func (r *webhookRepository) DeleteSubscription(ctx context.Context, id uuid.UUID, owner string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM webhook_subscriptions WHERE id = $1 AND owner = $2", id, owner)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// Enqueue queues an event for every active subscription to its type and
+// returns how many deliveries were queued.
+func (r *webhookRepository) Enqueue(ctx context.Context, owner string, eventID uuid.UUID, event string, payload []byte) (int64, error) {
+	query := `INSERT INTO webhook_deliveries (subscription_id, event_id, event, payload)
+			  SELECT id, $1, $2, $3 FROM webhook_subscriptions
+			  WHERE active AND $2 = ANY(events) AND owner = $4`
+
+	result, err := r.db.ExecContext(ctx, query, eventID, event, payload, owner)
+	if err != nil {
+		return 0, fmt.Errorf("failed to enqueue webhook deliveries: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows, nil
+}
+
+// ClaimDue returns up to limit pending deliveries whose next attempt is due,
+// pushing their next attempt back by lease so other instances skip them
+// while they are being sent.
+func (r *webhookRepository) ClaimDue(ctx context.Context, lease time.Duration, limit int) ([]*model.WebhookDelivery, error) {
+	query := `UPDATE webhook_deliveries SET next_attempt_at = NOW() + $1 * INTERVAL '1 millisecond'
+			  WHERE id IN (
+				SELECT id FROM webhook_deliveries
+				WHERE status = 'pending' AND next_attempt_at <= NOW()
+				ORDER BY next_attempt_at
+				LIMIT $2
+				FOR UPDATE SKIP LOCKED
+			  )
+			  RETURNING ` + deliveryColumns
+
+	return r.queryDeliveries(ctx, query, lease.Milliseconds(), limit)
+}
+
+// RecordAttempt logs an attempt and saves the delivery's resulting state.
+func (r *webhookRepository) RecordAttempt(ctx context.Context, delivery *model.WebhookDelivery, attempt *model.WebhookAttempt) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO webhook_attempts (id, delivery_id, attempt, status_code, error, duration_ms, created_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err = tx.ExecContext(ctx, query, attempt.ID, attempt.DeliveryID, attempt.Attempt, attempt.StatusCode, attempt.Error, attempt.DurationMS, attempt.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record webhook attempt: %w", err)
+	}
+
+	query = `UPDATE webhook_deliveries
+			 SET status = $2, attempts = $3, next_attempt_at = $4, last_status_code = $5, last_error = $6, delivered_at = $7
+			 WHERE id = $1`
+
+	_, err = tx.ExecContext(ctx, query, delivery.ID, delivery.Status, delivery.Attempts, delivery.NextAttemptAt, delivery.LastStatusCode, delivery.LastError, delivery.DeliveredAt)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ListDeliveries lists deliveries newest first, optionally only those with
+// the given status.
+func (r *webhookRepository) ListDeliveries(ctx context.Context, status string, limit, offset int) ([]*model.WebhookDelivery, error) {
+	query := `SELECT ` + deliveryColumns + `
+			  FROM webhook_deliveries
+			  WHERE $1::text = '' OR status = $1
+			  ORDER BY created_at DESC
+			  LIMIT $2 OFFSET $3`
+
+	return r.queryDeliveries(ctx, query, status, limit, offset)
+}
+
+func (r *webhookRepository) queryDeliveries(ctx context.Context, query string, args ...any) ([]*model.WebhookDelivery, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []*model.WebhookDelivery
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func (r *webhookRepository) ListAttempts(ctx context.Context, deliveryID uuid.UUID) ([]*model.WebhookAttempt, error) {
+	query := `SELECT id, delivery_id, attempt, status_code, error, duration_ms, created_at
+			  FROM webhook_attempts
+			  WHERE delivery_id = $1
+			  ORDER BY attempt`
+
+	rows, err := r.db.QueryContext(ctx, query, deliveryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook attempts: %w", err)
+	}
+	defer rows.Close()
+
+	var attempts []*model.WebhookAttempt
+	for rows.Next() {
+		var attempt model.WebhookAttempt
+		if err := rows.Scan(&attempt.ID, &attempt.DeliveryID, &attempt.Attempt, &attempt.StatusCode, &attempt.Error, &attempt.DurationMS, &attempt.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook attempt: %w", err)
+		}
+
+		attempts = append(attempts, &attempt)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list webhook attempts: %w", err)
+	}
+
+	return attempts, nil
+}
+
+// Requeue schedules a dead delivery to be sent again right away.
+func (r *webhookRepository) Requeue(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE webhook_deliveries SET status = 'pending', next_attempt_at = NOW()
+			  WHERE id = $1 AND status = 'dead'`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to requeue webhook delivery: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return ErrDeliveryNotFound
+	}
+
+	return nil
+}
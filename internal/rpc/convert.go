@@ -0,0 +1,93 @@
+package rpc
+
+import (
+	"time"
+
+	"github.com/ifaisalabid1/url-shortener/internal/model"
+	"github.com/ifaisalabid1/url-shortener/internal/rpc/shortenerv1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func fromCreateURLRequest(req *shortenerv1.CreateURLRequest) *model.CreateURLRequest {
+	createReq := &model.CreateURLRequest{
+		OriginalURL:     req.GetOriginalUrl(),
+		CustomCode:      req.CustomCode,
+		Domain:          req.GetDomain(),
+		LanguageTargets: req.GetLanguageTargets(),
+		ReuseExisting:   req.ReuseExisting,
+		Interstitial:    req.Interstitial,
+		Folder:          req.GetFolder(),
+		Tags:            req.GetTags(),
+	}
+
+	if req.ExpiresAt != nil {
+		expiresAt := req.GetExpiresAt().AsTime()
+		createReq.ExpiresAt = &expiresAt
+	}
+
+	if og := req.GetOpenGraph(); og != nil {
+		createReq.OpenGraph = &model.OpenGraph{
+			Title:       og.GetTitle(),
+			Description: og.GetDescription(),
+			Image:       og.GetImage(),
+		}
+	}
+
+	return createReq
+}
+
+func toURL(res *model.URLResponse) *shortenerv1.URL {
+	url := &shortenerv1.URL{
+		Id:              res.ID,
+		ShortCode:       res.ShortCode,
+		Domain:          res.Domain,
+		ShortUrl:        res.ShortURL,
+		OriginalUrl:     res.OriginalURL,
+		CreatedAt:       timestamppb.New(res.CreatedAt),
+		Clicks:          res.Clicks,
+		ExpiresAt:       toTimestamp(res.ExpiresAt),
+		Status:          res.Status,
+		LanguageTargets: res.LanguageTargets,
+		Interstitial:    res.Interstitial,
+		Folder:          res.Folder,
+		Tags:            res.Tags,
+	}
+
+	if res.OpenGraph != nil {
+		url.OpenGraph = &shortenerv1.OpenGraph{
+			Title:       res.OpenGraph.Title,
+			Description: res.OpenGraph.Description,
+			Image:       res.OpenGraph.Image,
+		}
+	}
+
+	return url
+}
+
+func toURLStats(stats *model.URLStats) *shortenerv1.URLStats {
+	res := &shortenerv1.URLStats{
+		ShortCode:   stats.ShortCode,
+		OriginalUrl: stats.OriginalURL,
+		Clicks:      stats.Clicks,
+		CreatedAt:   timestamppb.New(stats.CreatedAt),
+		Folder:      stats.Folder,
+		Tags:        stats.Tags,
+	}
+
+	for _, alias := range stats.Aliases {
+		res.Aliases = append(res.Aliases, &shortenerv1.AliasStats{
+			ShortCode: alias.ShortCode,
+			Clicks:    alias.Clicks,
+		})
+	}
+
+	return res
+}
+
+func toTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+
+	return timestamppb.New(*t)
+}
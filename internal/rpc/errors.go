@@ -0,0 +1,58 @@
+package rpc
+
+import (
+	"errors"
+
+	"github.com/ifaisalabid1/url-shortener/internal/model"
+	"github.com/ifaisalabid1/url-shortener/internal/repository"
+	"github.com/ifaisalabid1/url-shortener/internal/service"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatus maps service and repository errors to gRPC status codes, the way
+// the REST handlers map them to HTTP statuses.
+func (s *Server) toStatus(err error) error {
+	if fieldErrors := model.FieldErrors(err); fieldErrors != nil {
+		return validationStatus(fieldErrors)
+	}
+
+	switch {
+	case errors.Is(err, repository.ErrURLNotFound):
+		return status.Error(codes.NotFound, "url not found")
+	case errors.Is(err, repository.ErrDuplicateCode):
+		return status.Error(codes.AlreadyExists, "short code already exists")
+	case errors.Is(err, service.ErrURLExpired):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrURLDisabled), errors.Is(err, service.ErrURLBanned):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrInvalidURL),
+		errors.Is(err, service.ErrDestinationRejected),
+		errors.Is(err, service.ErrUnknownDomain),
+		errors.Is(err, service.ErrInterstitialOptOut):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		s.logger.Error("grpc request failed", "error", err)
+		return status.Error(codes.Internal, "internal error")
+	}
+}
+
+// validationStatus reports the rejected fields as a BadRequest detail.
+func validationStatus(fieldErrors []model.FieldError) error {
+	st := status.New(codes.InvalidArgument, "one or more fields are invalid")
+
+	details := &errdetails.BadRequest{}
+	for _, fe := range fieldErrors {
+		details.FieldViolations = append(details.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       fe.Field,
+			Description: fe.Message,
+		})
+	}
+
+	if withDetails, err := st.WithDetails(details); err == nil {
+		st = withDetails
+	}
+
+	return st.Err()
+}
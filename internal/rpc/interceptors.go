@@ -0,0 +1,161 @@
+package rpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"log/slog"
+	"net"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/ifaisalabid1/url-shortener/internal/model"
+	"github.com/ifaisalabid1/url-shortener/internal/rpc/shortenerv1"
+	"github.com/ifaisalabid1/url-shortener/internal/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// recoverer turns a panicking handler into an Internal error instead of
+// taking the whole server down.
+func recoverer(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (res any, err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				logger.Error("grpc handler panicked", "method", info.FullMethod, "panic", p, "stack", string(debug.Stack()))
+				err = status.Error(codes.Internal, "internal error")
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+func logRequests(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+
+		res, err := handler(ctx, req)
+
+		logger.Info(
+			"grpc request completed",
+			"method", info.FullMethod,
+			"code", status.Code(err).String(),
+			"duration", time.Since(start),
+		)
+
+		return res, err
+	}
+}
+
+// mutatingMethods are the RPCs that change links.
+var mutatingMethods = map[string]bool{
+	shortenerv1.URLService_CreateURL_FullMethodName:       true,
+	shortenerv1.URLService_BatchCreateURLs_FullMethodName: true,
+	shortenerv1.URLService_DeleteURL_FullMethodName:       true,
+}
+
+// adminMethods are the RPCs that require the admin API key.
+var adminMethods = map[string]bool{
+	shortenerv1.URLService_ListURLs_FullMethodName:  true,
+	shortenerv1.URLService_DeleteURL_FullMethodName: true,
+}
+
+// audit records every authenticated mutating call, along with rejected
+// attempts at admin-only mutating calls, like the REST API's audit
+// middleware. Entries have the method "GRPC" and the gRPC status code as
+// their status.
+func audit(auditService service.AuditService, logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		actor := service.ActorFromContext(ctx)
+
+		if !mutatingMethods[info.FullMethod] || (actor.Trust != model.TrustTrusted && !adminMethods[info.FullMethod]) {
+			return handler(ctx, req)
+		}
+
+		start := time.Now()
+
+		res, err := handler(ctx, req)
+
+		code := status.Code(err)
+		entry := &model.AuditEntry{
+			Actor:      actor.Name,
+			IP:         actor.IP,
+			Method:     "GRPC",
+			Path:       info.FullMethod,
+			Route:      info.FullMethod,
+			Status:     int(code),
+			Outcome:    auditOutcome(code),
+			DurationMS: time.Since(start).Milliseconds(),
+			CreatedAt:  start.UTC(),
+		}
+
+		if err := auditService.Record(context.WithoutCancel(ctx), entry); err != nil {
+			logger.Error("failed to record audit entry", "method", info.FullMethod, "error", err)
+		}
+
+		return res, err
+	}
+}
+
+func auditOutcome(code codes.Code) string {
+	switch code {
+	case codes.OK:
+		return model.AuditSuccess
+	case codes.Unauthenticated, codes.PermissionDenied:
+		return model.AuditDenied
+	default:
+		return model.AuditFailure
+	}
+}
+
+// identify attaches the calling actor to the context. Callers sending the
+// admin API key as a bearer token are trusted; everyone else is anonymous.
+func identify(apiKey string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		actor := &model.Actor{
+			Name:  model.TrustAnonymous,
+			IP:    peerIP(ctx),
+			Trust: model.TrustAnonymous,
+		}
+
+		if isAdmin(ctx, apiKey) {
+			actor.Name = "admin"
+			actor.Trust = model.TrustTrusted
+		}
+
+		return handler(service.WithActor(ctx, actor), req)
+	}
+}
+
+func isAdmin(ctx context.Context, apiKey string) bool {
+	if apiKey == "" {
+		return false
+	}
+
+	for _, value := range metadata.ValueFromIncomingContext(ctx, "authorization") {
+		token, ok := strings.CutPrefix(value, "Bearer ")
+		if ok && subtle.ConstantTimeCompare([]byte(token), []byte(apiKey)) == 1 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+
+	return host
+}
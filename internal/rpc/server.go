@@ -0,0 +1,216 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/ifaisalabid1/url-shortener/internal/model"
+	"github.com/ifaisalabid1/url-shortener/internal/rpc/shortenerv1"
+	"github.com/ifaisalabid1/url-shortener/internal/service"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// maxBatchSize caps the number of links created by one BatchCreateURLs call.
+const maxBatchSize = 100
+
+// Server implements the gRPC URLService on top of the same service layer as
+// the REST API.
+type Server struct {
+	shortenerv1.UnimplementedURLServiceServer
+	urlService    service.URLService
+	domainService service.DomainService
+	logger        *slog.Logger
+}
+
+func NewServer(urlService service.URLService, domainService service.DomainService, logger *slog.Logger) *Server {
+	return &Server{
+		urlService:    urlService,
+		domainService: domainService,
+		logger:        logger,
+	}
+}
+
+// NewGRPCServer returns a gRPC server serving s. Callers presenting
+// adminAPIKey are trusted, as on the REST API, and their mutating calls are
+// recorded in the audit log. The recoverer runs innermost so the request and
+// audit logs see a panic as an Internal error.
+func NewGRPCServer(s *Server, auditService service.AuditService, adminAPIKey string) *grpc.Server {
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		logRequests(s.logger),
+		identify(adminAPIKey),
+		audit(auditService, s.logger),
+		recoverer(s.logger),
+	))
+
+	shortenerv1.RegisterURLServiceServer(server, s)
+	reflection.Register(server)
+
+	return server
+}
+
+func (s *Server) CreateURL(ctx context.Context, req *shortenerv1.CreateURLRequest) (*shortenerv1.CreateURLResponse, error) {
+	url, err := s.createURL(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &shortenerv1.CreateURLResponse{Url: url}, nil
+}
+
+func (s *Server) BatchCreateURLs(ctx context.Context, req *shortenerv1.BatchCreateURLsRequest) (*shortenerv1.BatchCreateURLsResponse, error) {
+	if len(req.GetRequests()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "requests must not be empty")
+	}
+
+	if len(req.GetRequests()) > maxBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d links can be created at once", maxBatchSize)
+	}
+
+	res := &shortenerv1.BatchCreateURLsResponse{}
+	for _, item := range req.GetRequests() {
+		url, err := s.createURL(ctx, item)
+		if err != nil {
+			res.Results = append(res.Results, &shortenerv1.BatchCreateURLsResult{
+				Result: &shortenerv1.BatchCreateURLsResult_Error{Error: batchError(err)},
+			})
+
+			continue
+		}
+
+		res.Results = append(res.Results, &shortenerv1.BatchCreateURLsResult{
+			Result: &shortenerv1.BatchCreateURLsResult_Url{Url: url},
+		})
+	}
+
+	return res, nil
+}
+
+func (s *Server) createURL(ctx context.Context, req *shortenerv1.CreateURLRequest) (*shortenerv1.URL, error) {
+	res, err := s.urlService.CreateShortURL(ctx, fromCreateURLRequest(req))
+	if err != nil {
+		return nil, s.toStatus(err)
+	}
+
+	return toURL(res), nil
+}
+
+func (s *Server) GetURL(ctx context.Context, req *shortenerv1.GetURLRequest) (*shortenerv1.GetURLResponse, error) {
+	ctx, err := s.withDomain(ctx, req.GetDomain())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.urlService.GetURLPreview(ctx, req.GetShortCode())
+	if err != nil {
+		return nil, s.toStatus(err)
+	}
+
+	return &shortenerv1.GetURLResponse{Url: toURL(res)}, nil
+}
+
+func (s *Server) GetURLStats(ctx context.Context, req *shortenerv1.GetURLStatsRequest) (*shortenerv1.GetURLStatsResponse, error) {
+	ctx, err := s.withDomain(ctx, req.GetDomain())
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.urlService.GetURLStats(ctx, req.GetShortCode())
+	if err != nil {
+		return nil, s.toStatus(err)
+	}
+
+	return &shortenerv1.GetURLStatsResponse{Stats: toURLStats(stats)}, nil
+}
+
+// ListURLs lists every link. Like the REST API it needs the admin API key.
+func (s *Server) ListURLs(ctx context.Context, req *shortenerv1.ListURLsRequest) (*shortenerv1.ListURLsResponse, error) {
+	if service.ActorFromContext(ctx).Trust != model.TrustTrusted {
+		return nil, status.Error(codes.PermissionDenied, "listing links requires the admin API key")
+	}
+
+	limit := int(req.GetLimit())
+	if limit == 0 {
+		limit = 50
+	}
+
+	if limit < 1 || limit > 500 {
+		return nil, status.Error(codes.InvalidArgument, "limit must be between 1 and 500")
+	}
+
+	if req.GetOffset() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "offset must not be negative")
+	}
+
+	urls, err := s.urlService.ListURLs(ctx, model.URLFilter{
+		Tag:    req.GetTag(),
+		Folder: req.GetFolder(),
+		Limit:  limit,
+		Offset: int(req.GetOffset()),
+	})
+	if err != nil {
+		return nil, s.toStatus(err)
+	}
+
+	res := &shortenerv1.ListURLsResponse{}
+	for _, url := range urls {
+		res.Urls = append(res.Urls, toURL(url))
+	}
+
+	return res, nil
+}
+
+// DeleteURL moves a link to the trash. Like the REST API it needs the admin
+// API key, since links have no owner to check the caller against.
+func (s *Server) DeleteURL(ctx context.Context, req *shortenerv1.DeleteURLRequest) (*shortenerv1.DeleteURLResponse, error) {
+	if service.ActorFromContext(ctx).Trust != model.TrustTrusted {
+		return nil, status.Error(codes.PermissionDenied, "deleting links requires the admin API key")
+	}
+
+	ctx, err := s.withDomain(ctx, req.GetDomain())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.urlService.DeleteURL(ctx, req.GetShortCode()); err != nil {
+		return nil, s.toStatus(err)
+	}
+
+	return &shortenerv1.DeleteURLResponse{}, nil
+}
+
+// withDomain scopes ctx to a registered branded domain, like the REST API's
+// domain query parameter. An empty host means the default domain.
+func (s *Server) withDomain(ctx context.Context, host string) (context.Context, error) {
+	if host == "" {
+		return ctx, nil
+	}
+
+	host = service.NormalizeHost(host)
+	if !s.domainService.Has(host) {
+		return nil, status.Error(codes.InvalidArgument, service.ErrUnknownDomain.Error())
+	}
+
+	return service.WithDomain(ctx, host), nil
+}
+
+// batchError flattens a failed item's status, including any rejected
+// fields, into the batch response.
+func batchError(err error) *shortenerv1.Error {
+	st := status.Convert(err)
+	message := st.Message()
+
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, violation := range badRequest.GetFieldViolations() {
+				message += fmt.Sprintf("; %s %s", violation.GetField(), violation.GetDescription())
+			}
+		}
+	}
+
+	return &shortenerv1.Error{Code: int32(st.Code()), Message: message}
+}
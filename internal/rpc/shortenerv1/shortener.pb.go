@@ -0,0 +1,1371 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: shortener/v1/shortener.proto
+
+package shortenerv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type OpenGraph struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Image         string                 `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OpenGraph) Reset() {
+	*x = OpenGraph{}
+	mi := &file_shortener_v1_shortener_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenGraph) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenGraph) ProtoMessage() {}
+
+func (x *OpenGraph) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v1_shortener_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenGraph.ProtoReflect.Descriptor instead.
+func (*OpenGraph) Descriptor() ([]byte, []int) {
+	return file_shortener_v1_shortener_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OpenGraph) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *OpenGraph) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *OpenGraph) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+type URL struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ShortCode       string                 `protobuf:"bytes,2,opt,name=short_code,json=shortCode,proto3" json:"short_code,omitempty"`
+	Domain          string                 `protobuf:"bytes,3,opt,name=domain,proto3" json:"domain,omitempty"`
+	ShortUrl        string                 `protobuf:"bytes,4,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	OriginalUrl     string                 `protobuf:"bytes,5,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Clicks          int64                  `protobuf:"varint,7,opt,name=clicks,proto3" json:"clicks,omitempty"`
+	ExpiresAt       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Status          string                 `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
+	LanguageTargets map[string]string      `protobuf:"bytes,10,rep,name=language_targets,json=languageTargets,proto3" json:"language_targets,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	OpenGraph       *OpenGraph             `protobuf:"bytes,11,opt,name=open_graph,json=openGraph,proto3" json:"open_graph,omitempty"`
+	Interstitial    *bool                  `protobuf:"varint,12,opt,name=interstitial,proto3,oneof" json:"interstitial,omitempty"`
+	Folder          string                 `protobuf:"bytes,13,opt,name=folder,proto3" json:"folder,omitempty"`
+	Tags            []string               `protobuf:"bytes,14,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *URL) Reset() {
+	*x = URL{}
+	mi := &file_shortener_v1_shortener_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *URL) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*URL) ProtoMessage() {}
+
+func (x *URL) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v1_shortener_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use URL.ProtoReflect.Descriptor instead.
+func (*URL) Descriptor() ([]byte, []int) {
+	return file_shortener_v1_shortener_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *URL) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *URL) GetShortCode() string {
+	if x != nil {
+		return x.ShortCode
+	}
+	return ""
+}
+
+func (x *URL) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *URL) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *URL) GetOriginalUrl() string {
+	if x != nil {
+		return x.OriginalUrl
+	}
+	return ""
+}
+
+func (x *URL) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *URL) GetClicks() int64 {
+	if x != nil {
+		return x.Clicks
+	}
+	return 0
+}
+
+func (x *URL) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *URL) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *URL) GetLanguageTargets() map[string]string {
+	if x != nil {
+		return x.LanguageTargets
+	}
+	return nil
+}
+
+func (x *URL) GetOpenGraph() *OpenGraph {
+	if x != nil {
+		return x.OpenGraph
+	}
+	return nil
+}
+
+func (x *URL) GetInterstitial() bool {
+	if x != nil && x.Interstitial != nil {
+		return *x.Interstitial
+	}
+	return false
+}
+
+func (x *URL) GetFolder() string {
+	if x != nil {
+		return x.Folder
+	}
+	return ""
+}
+
+func (x *URL) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type CreateURLRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	OriginalUrl string                 `protobuf:"bytes,1,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	CustomCode  *string                `protobuf:"bytes,2,opt,name=custom_code,json=customCode,proto3,oneof" json:"custom_code,omitempty"`
+	// Branded short domain to create the link on. Empty for the default domain.
+	Domain          string                 `protobuf:"bytes,3,opt,name=domain,proto3" json:"domain,omitempty"`
+	ExpiresAt       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	LanguageTargets map[string]string      `protobuf:"bytes,5,rep,name=language_targets,json=languageTargets,proto3" json:"language_targets,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	OpenGraph       *OpenGraph             `protobuf:"bytes,6,opt,name=open_graph,json=openGraph,proto3" json:"open_graph,omitempty"`
+	ReuseExisting   *bool                  `protobuf:"varint,7,opt,name=reuse_existing,json=reuseExisting,proto3,oneof" json:"reuse_existing,omitempty"`
+	Interstitial    *bool                  `protobuf:"varint,8,opt,name=interstitial,proto3,oneof" json:"interstitial,omitempty"`
+	Folder          string                 `protobuf:"bytes,9,opt,name=folder,proto3" json:"folder,omitempty"`
+	Tags            []string               `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateURLRequest) Reset() {
+	*x = CreateURLRequest{}
+	mi := &file_shortener_v1_shortener_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateURLRequest) ProtoMessage() {}
+
+func (x *CreateURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v1_shortener_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateURLRequest.ProtoReflect.Descriptor instead.
+func (*CreateURLRequest) Descriptor() ([]byte, []int) {
+	return file_shortener_v1_shortener_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateURLRequest) GetOriginalUrl() string {
+	if x != nil {
+		return x.OriginalUrl
+	}
+	return ""
+}
+
+func (x *CreateURLRequest) GetCustomCode() string {
+	if x != nil && x.CustomCode != nil {
+		return *x.CustomCode
+	}
+	return ""
+}
+
+func (x *CreateURLRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *CreateURLRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *CreateURLRequest) GetLanguageTargets() map[string]string {
+	if x != nil {
+		return x.LanguageTargets
+	}
+	return nil
+}
+
+func (x *CreateURLRequest) GetOpenGraph() *OpenGraph {
+	if x != nil {
+		return x.OpenGraph
+	}
+	return nil
+}
+
+func (x *CreateURLRequest) GetReuseExisting() bool {
+	if x != nil && x.ReuseExisting != nil {
+		return *x.ReuseExisting
+	}
+	return false
+}
+
+func (x *CreateURLRequest) GetInterstitial() bool {
+	if x != nil && x.Interstitial != nil {
+		return *x.Interstitial
+	}
+	return false
+}
+
+func (x *CreateURLRequest) GetFolder() string {
+	if x != nil {
+		return x.Folder
+	}
+	return ""
+}
+
+func (x *CreateURLRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type CreateURLResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           *URL                   `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateURLResponse) Reset() {
+	*x = CreateURLResponse{}
+	mi := &file_shortener_v1_shortener_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateURLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateURLResponse) ProtoMessage() {}
+
+func (x *CreateURLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v1_shortener_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateURLResponse.ProtoReflect.Descriptor instead.
+func (*CreateURLResponse) Descriptor() ([]byte, []int) {
+	return file_shortener_v1_shortener_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateURLResponse) GetUrl() *URL {
+	if x != nil {
+		return x.Url
+	}
+	return nil
+}
+
+type BatchCreateURLsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Requests      []*CreateURLRequest    `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchCreateURLsRequest) Reset() {
+	*x = BatchCreateURLsRequest{}
+	mi := &file_shortener_v1_shortener_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchCreateURLsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCreateURLsRequest) ProtoMessage() {}
+
+func (x *BatchCreateURLsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v1_shortener_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCreateURLsRequest.ProtoReflect.Descriptor instead.
+func (*BatchCreateURLsRequest) Descriptor() ([]byte, []int) {
+	return file_shortener_v1_shortener_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BatchCreateURLsRequest) GetRequests() []*CreateURLRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+type BatchCreateURLsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One result per request, in the same order.
+	Results       []*BatchCreateURLsResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchCreateURLsResponse) Reset() {
+	*x = BatchCreateURLsResponse{}
+	mi := &file_shortener_v1_shortener_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchCreateURLsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCreateURLsResponse) ProtoMessage() {}
+
+func (x *BatchCreateURLsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v1_shortener_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCreateURLsResponse.ProtoReflect.Descriptor instead.
+func (*BatchCreateURLsResponse) Descriptor() ([]byte, []int) {
+	return file_shortener_v1_shortener_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BatchCreateURLsResponse) GetResults() []*BatchCreateURLsResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type BatchCreateURLsResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*BatchCreateURLsResult_Url
+	//	*BatchCreateURLsResult_Error
+	Result        isBatchCreateURLsResult_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchCreateURLsResult) Reset() {
+	*x = BatchCreateURLsResult{}
+	mi := &file_shortener_v1_shortener_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchCreateURLsResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCreateURLsResult) ProtoMessage() {}
+
+func (x *BatchCreateURLsResult) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v1_shortener_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCreateURLsResult.ProtoReflect.Descriptor instead.
+func (*BatchCreateURLsResult) Descriptor() ([]byte, []int) {
+	return file_shortener_v1_shortener_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BatchCreateURLsResult) GetResult() isBatchCreateURLsResult_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *BatchCreateURLsResult) GetUrl() *URL {
+	if x != nil {
+		if x, ok := x.Result.(*BatchCreateURLsResult_Url); ok {
+			return x.Url
+		}
+	}
+	return nil
+}
+
+func (x *BatchCreateURLsResult) GetError() *Error {
+	if x != nil {
+		if x, ok := x.Result.(*BatchCreateURLsResult_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isBatchCreateURLsResult_Result interface {
+	isBatchCreateURLsResult_Result()
+}
+
+type BatchCreateURLsResult_Url struct {
+	Url *URL `protobuf:"bytes,1,opt,name=url,proto3,oneof"`
+}
+
+type BatchCreateURLsResult_Error struct {
+	Error *Error `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*BatchCreateURLsResult_Url) isBatchCreateURLsResult_Result() {}
+
+func (*BatchCreateURLsResult_Error) isBatchCreateURLsResult_Result() {}
+
+// Error is a failed item of a batch. Code is a google.rpc.Code value.
+type Error struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Error) Reset() {
+	*x = Error{}
+	mi := &file_shortener_v1_shortener_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Error) ProtoMessage() {}
+
+func (x *Error) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v1_shortener_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Error.ProtoReflect.Descriptor instead.
+func (*Error) Descriptor() ([]byte, []int) {
+	return file_shortener_v1_shortener_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Error) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *Error) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type GetURLRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortCode     string                 `protobuf:"bytes,1,opt,name=short_code,json=shortCode,proto3" json:"short_code,omitempty"`
+	Domain        string                 `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetURLRequest) Reset() {
+	*x = GetURLRequest{}
+	mi := &file_shortener_v1_shortener_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetURLRequest) ProtoMessage() {}
+
+func (x *GetURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v1_shortener_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetURLRequest.ProtoReflect.Descriptor instead.
+func (*GetURLRequest) Descriptor() ([]byte, []int) {
+	return file_shortener_v1_shortener_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetURLRequest) GetShortCode() string {
+	if x != nil {
+		return x.ShortCode
+	}
+	return ""
+}
+
+func (x *GetURLRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+type GetURLResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           *URL                   `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetURLResponse) Reset() {
+	*x = GetURLResponse{}
+	mi := &file_shortener_v1_shortener_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetURLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetURLResponse) ProtoMessage() {}
+
+func (x *GetURLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v1_shortener_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetURLResponse.ProtoReflect.Descriptor instead.
+func (*GetURLResponse) Descriptor() ([]byte, []int) {
+	return file_shortener_v1_shortener_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetURLResponse) GetUrl() *URL {
+	if x != nil {
+		return x.Url
+	}
+	return nil
+}
+
+type GetURLStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortCode     string                 `protobuf:"bytes,1,opt,name=short_code,json=shortCode,proto3" json:"short_code,omitempty"`
+	Domain        string                 `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetURLStatsRequest) Reset() {
+	*x = GetURLStatsRequest{}
+	mi := &file_shortener_v1_shortener_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetURLStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetURLStatsRequest) ProtoMessage() {}
+
+func (x *GetURLStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v1_shortener_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetURLStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetURLStatsRequest) Descriptor() ([]byte, []int) {
+	return file_shortener_v1_shortener_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetURLStatsRequest) GetShortCode() string {
+	if x != nil {
+		return x.ShortCode
+	}
+	return ""
+}
+
+func (x *GetURLStatsRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+type AliasStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortCode     string                 `protobuf:"bytes,1,opt,name=short_code,json=shortCode,proto3" json:"short_code,omitempty"`
+	Clicks        int64                  `protobuf:"varint,2,opt,name=clicks,proto3" json:"clicks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AliasStats) Reset() {
+	*x = AliasStats{}
+	mi := &file_shortener_v1_shortener_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AliasStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AliasStats) ProtoMessage() {}
+
+func (x *AliasStats) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v1_shortener_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AliasStats.ProtoReflect.Descriptor instead.
+func (*AliasStats) Descriptor() ([]byte, []int) {
+	return file_shortener_v1_shortener_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AliasStats) GetShortCode() string {
+	if x != nil {
+		return x.ShortCode
+	}
+	return ""
+}
+
+func (x *AliasStats) GetClicks() int64 {
+	if x != nil {
+		return x.Clicks
+	}
+	return 0
+}
+
+type URLStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortCode     string                 `protobuf:"bytes,1,opt,name=short_code,json=shortCode,proto3" json:"short_code,omitempty"`
+	OriginalUrl   string                 `protobuf:"bytes,2,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	Clicks        int64                  `protobuf:"varint,3,opt,name=clicks,proto3" json:"clicks,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Folder        string                 `protobuf:"bytes,5,opt,name=folder,proto3" json:"folder,omitempty"`
+	Tags          []string               `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	Aliases       []*AliasStats          `protobuf:"bytes,7,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *URLStats) Reset() {
+	*x = URLStats{}
+	mi := &file_shortener_v1_shortener_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *URLStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*URLStats) ProtoMessage() {}
+
+func (x *URLStats) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v1_shortener_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use URLStats.ProtoReflect.Descriptor instead.
+func (*URLStats) Descriptor() ([]byte, []int) {
+	return file_shortener_v1_shortener_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *URLStats) GetShortCode() string {
+	if x != nil {
+		return x.ShortCode
+	}
+	return ""
+}
+
+func (x *URLStats) GetOriginalUrl() string {
+	if x != nil {
+		return x.OriginalUrl
+	}
+	return ""
+}
+
+func (x *URLStats) GetClicks() int64 {
+	if x != nil {
+		return x.Clicks
+	}
+	return 0
+}
+
+func (x *URLStats) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *URLStats) GetFolder() string {
+	if x != nil {
+		return x.Folder
+	}
+	return ""
+}
+
+func (x *URLStats) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *URLStats) GetAliases() []*AliasStats {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+type GetURLStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stats         *URLStats              `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetURLStatsResponse) Reset() {
+	*x = GetURLStatsResponse{}
+	mi := &file_shortener_v1_shortener_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetURLStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetURLStatsResponse) ProtoMessage() {}
+
+func (x *GetURLStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v1_shortener_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetURLStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetURLStatsResponse) Descriptor() ([]byte, []int) {
+	return file_shortener_v1_shortener_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetURLStatsResponse) GetStats() *URLStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type ListURLsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Tag    string                 `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	Folder string                 `protobuf:"bytes,2,opt,name=folder,proto3" json:"folder,omitempty"`
+	// Between 1 and 500; 0 means 50.
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32 `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListURLsRequest) Reset() {
+	*x = ListURLsRequest{}
+	mi := &file_shortener_v1_shortener_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListURLsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListURLsRequest) ProtoMessage() {}
+
+func (x *ListURLsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v1_shortener_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListURLsRequest.ProtoReflect.Descriptor instead.
+func (*ListURLsRequest) Descriptor() ([]byte, []int) {
+	return file_shortener_v1_shortener_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListURLsRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *ListURLsRequest) GetFolder() string {
+	if x != nil {
+		return x.Folder
+	}
+	return ""
+}
+
+func (x *ListURLsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListURLsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListURLsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Urls          []*URL                 `protobuf:"bytes,1,rep,name=urls,proto3" json:"urls,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListURLsResponse) Reset() {
+	*x = ListURLsResponse{}
+	mi := &file_shortener_v1_shortener_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListURLsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListURLsResponse) ProtoMessage() {}
+
+func (x *ListURLsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v1_shortener_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListURLsResponse.ProtoReflect.Descriptor instead.
+func (*ListURLsResponse) Descriptor() ([]byte, []int) {
+	return file_shortener_v1_shortener_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListURLsResponse) GetUrls() []*URL {
+	if x != nil {
+		return x.Urls
+	}
+	return nil
+}
+
+type DeleteURLRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortCode     string                 `protobuf:"bytes,1,opt,name=short_code,json=shortCode,proto3" json:"short_code,omitempty"`
+	Domain        string                 `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteURLRequest) Reset() {
+	*x = DeleteURLRequest{}
+	mi := &file_shortener_v1_shortener_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteURLRequest) ProtoMessage() {}
+
+func (x *DeleteURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v1_shortener_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteURLRequest.ProtoReflect.Descriptor instead.
+func (*DeleteURLRequest) Descriptor() ([]byte, []int) {
+	return file_shortener_v1_shortener_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DeleteURLRequest) GetShortCode() string {
+	if x != nil {
+		return x.ShortCode
+	}
+	return ""
+}
+
+func (x *DeleteURLRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+type DeleteURLResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteURLResponse) Reset() {
+	*x = DeleteURLResponse{}
+	mi := &file_shortener_v1_shortener_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteURLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteURLResponse) ProtoMessage() {}
+
+func (x *DeleteURLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v1_shortener_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteURLResponse.ProtoReflect.Descriptor instead.
+func (*DeleteURLResponse) Descriptor() ([]byte, []int) {
+	return file_shortener_v1_shortener_proto_rawDescGZIP(), []int{17}
+}
+
+var File_shortener_v1_shortener_proto protoreflect.FileDescriptor
+
+const file_shortener_v1_shortener_proto_rawDesc = "" +
+	"\n" +
+	"\x1cshortener/v1/shortener.proto\x12\fshortener.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"Y\n" +
+	"\tOpenGraph\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05image\x18\x03 \x01(\tR\x05image\"\xe7\x04\n" +
+	"\x03URL\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"short_code\x18\x02 \x01(\tR\tshortCode\x12\x16\n" +
+	"\x06domain\x18\x03 \x01(\tR\x06domain\x12\x1b\n" +
+	"\tshort_url\x18\x04 \x01(\tR\bshortUrl\x12!\n" +
+	"\foriginal_url\x18\x05 \x01(\tR\voriginalUrl\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x16\n" +
+	"\x06clicks\x18\a \x01(\x03R\x06clicks\x129\n" +
+	"\n" +
+	"expires_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x12Q\n" +
+	"\x10language_targets\x18\n" +
+	" \x03(\v2&.shortener.v1.URL.LanguageTargetsEntryR\x0flanguageTargets\x126\n" +
+	"\n" +
+	"open_graph\x18\v \x01(\v2\x17.shortener.v1.OpenGraphR\topenGraph\x12'\n" +
+	"\finterstitial\x18\f \x01(\bH\x00R\finterstitial\x88\x01\x01\x12\x16\n" +
+	"\x06folder\x18\r \x01(\tR\x06folder\x12\x12\n" +
+	"\x04tags\x18\x0e \x03(\tR\x04tags\x1aB\n" +
+	"\x14LanguageTargetsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x0f\n" +
+	"\r_interstitial\"\xbf\x04\n" +
+	"\x10CreateURLRequest\x12!\n" +
+	"\foriginal_url\x18\x01 \x01(\tR\voriginalUrl\x12$\n" +
+	"\vcustom_code\x18\x02 \x01(\tH\x00R\n" +
+	"customCode\x88\x01\x01\x12\x16\n" +
+	"\x06domain\x18\x03 \x01(\tR\x06domain\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12^\n" +
+	"\x10language_targets\x18\x05 \x03(\v23.shortener.v1.CreateURLRequest.LanguageTargetsEntryR\x0flanguageTargets\x126\n" +
+	"\n" +
+	"open_graph\x18\x06 \x01(\v2\x17.shortener.v1.OpenGraphR\topenGraph\x12*\n" +
+	"\x0ereuse_existing\x18\a \x01(\bH\x01R\rreuseExisting\x88\x01\x01\x12'\n" +
+	"\finterstitial\x18\b \x01(\bH\x02R\finterstitial\x88\x01\x01\x12\x16\n" +
+	"\x06folder\x18\t \x01(\tR\x06folder\x12\x12\n" +
+	"\x04tags\x18\n" +
+	" \x03(\tR\x04tags\x1aB\n" +
+	"\x14LanguageTargetsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x0e\n" +
+	"\f_custom_codeB\x11\n" +
+	"\x0f_reuse_existingB\x0f\n" +
+	"\r_interstitial\"8\n" +
+	"\x11CreateURLResponse\x12#\n" +
+	"\x03url\x18\x01 \x01(\v2\x11.shortener.v1.URLR\x03url\"T\n" +
+	"\x16BatchCreateURLsRequest\x12:\n" +
+	"\brequests\x18\x01 \x03(\v2\x1e.shortener.v1.CreateURLRequestR\brequests\"X\n" +
+	"\x17BatchCreateURLsResponse\x12=\n" +
+	"\aresults\x18\x01 \x03(\v2#.shortener.v1.BatchCreateURLsResultR\aresults\"u\n" +
+	"\x15BatchCreateURLsResult\x12%\n" +
+	"\x03url\x18\x01 \x01(\v2\x11.shortener.v1.URLH\x00R\x03url\x12+\n" +
+	"\x05error\x18\x02 \x01(\v2\x13.shortener.v1.ErrorH\x00R\x05errorB\b\n" +
+	"\x06result\"5\n" +
+	"\x05Error\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"F\n" +
+	"\rGetURLRequest\x12\x1d\n" +
+	"\n" +
+	"short_code\x18\x01 \x01(\tR\tshortCode\x12\x16\n" +
+	"\x06domain\x18\x02 \x01(\tR\x06domain\"5\n" +
+	"\x0eGetURLResponse\x12#\n" +
+	"\x03url\x18\x01 \x01(\v2\x11.shortener.v1.URLR\x03url\"K\n" +
+	"\x12GetURLStatsRequest\x12\x1d\n" +
+	"\n" +
+	"short_code\x18\x01 \x01(\tR\tshortCode\x12\x16\n" +
+	"\x06domain\x18\x02 \x01(\tR\x06domain\"C\n" +
+	"\n" +
+	"AliasStats\x12\x1d\n" +
+	"\n" +
+	"short_code\x18\x01 \x01(\tR\tshortCode\x12\x16\n" +
+	"\x06clicks\x18\x02 \x01(\x03R\x06clicks\"\xff\x01\n" +
+	"\bURLStats\x12\x1d\n" +
+	"\n" +
+	"short_code\x18\x01 \x01(\tR\tshortCode\x12!\n" +
+	"\foriginal_url\x18\x02 \x01(\tR\voriginalUrl\x12\x16\n" +
+	"\x06clicks\x18\x03 \x01(\x03R\x06clicks\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x16\n" +
+	"\x06folder\x18\x05 \x01(\tR\x06folder\x12\x12\n" +
+	"\x04tags\x18\x06 \x03(\tR\x04tags\x122\n" +
+	"\aaliases\x18\a \x03(\v2\x18.shortener.v1.AliasStatsR\aaliases\"C\n" +
+	"\x13GetURLStatsResponse\x12,\n" +
+	"\x05stats\x18\x01 \x01(\v2\x16.shortener.v1.URLStatsR\x05stats\"i\n" +
+	"\x0fListURLsRequest\x12\x10\n" +
+	"\x03tag\x18\x01 \x01(\tR\x03tag\x12\x16\n" +
+	"\x06folder\x18\x02 \x01(\tR\x06folder\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\"9\n" +
+	"\x10ListURLsResponse\x12%\n" +
+	"\x04urls\x18\x01 \x03(\v2\x11.shortener.v1.URLR\x04urls\"I\n" +
+	"\x10DeleteURLRequest\x12\x1d\n" +
+	"\n" +
+	"short_code\x18\x01 \x01(\tR\tshortCode\x12\x16\n" +
+	"\x06domain\x18\x02 \x01(\tR\x06domain\"\x13\n" +
+	"\x11DeleteURLResponse2\xec\x03\n" +
+	"\n" +
+	"URLService\x12L\n" +
+	"\tCreateURL\x12\x1e.shortener.v1.CreateURLRequest\x1a\x1f.shortener.v1.CreateURLResponse\x12^\n" +
+	"\x0fBatchCreateURLs\x12$.shortener.v1.BatchCreateURLsRequest\x1a%.shortener.v1.BatchCreateURLsResponse\x12C\n" +
+	"\x06GetURL\x12\x1b.shortener.v1.GetURLRequest\x1a\x1c.shortener.v1.GetURLResponse\x12R\n" +
+	"\vGetURLStats\x12 .shortener.v1.GetURLStatsRequest\x1a!.shortener.v1.GetURLStatsResponse\x12I\n" +
+	"\bListURLs\x12\x1d.shortener.v1.ListURLsRequest\x1a\x1e.shortener.v1.ListURLsResponse\x12L\n" +
+	"\tDeleteURL\x12\x1e.shortener.v1.DeleteURLRequest\x1a\x1f.shortener.v1.DeleteURLResponseBLZJgithub.com/ifaisalabid1/url-shortener/internal/rpc/shortenerv1;shortenerv1b\x06proto3"
+
+var (
+	file_shortener_v1_shortener_proto_rawDescOnce sync.Once
+	file_shortener_v1_shortener_proto_rawDescData []byte
+)
+
+func file_shortener_v1_shortener_proto_rawDescGZIP() []byte {
+	file_shortener_v1_shortener_proto_rawDescOnce.Do(func() {
+		file_shortener_v1_shortener_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_shortener_v1_shortener_proto_rawDesc), len(file_shortener_v1_shortener_proto_rawDesc)))
+	})
+	return file_shortener_v1_shortener_proto_rawDescData
+}
+
+var file_shortener_v1_shortener_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_shortener_v1_shortener_proto_goTypes = []any{
+	(*OpenGraph)(nil),               // 0: shortener.v1.OpenGraph
+	(*URL)(nil),                     // 1: shortener.v1.URL
+	(*CreateURLRequest)(nil),        // 2: shortener.v1.CreateURLRequest
+	(*CreateURLResponse)(nil),       // 3: shortener.v1.CreateURLResponse
+	(*BatchCreateURLsRequest)(nil),  // 4: shortener.v1.BatchCreateURLsRequest
+	(*BatchCreateURLsResponse)(nil), // 5: shortener.v1.BatchCreateURLsResponse
+	(*BatchCreateURLsResult)(nil),   // 6: shortener.v1.BatchCreateURLsResult
+	(*Error)(nil),                   // 7: shortener.v1.Error
+	(*GetURLRequest)(nil),           // 8: shortener.v1.GetURLRequest
+	(*GetURLResponse)(nil),          // 9: shortener.v1.GetURLResponse
+	(*GetURLStatsRequest)(nil),      // 10: shortener.v1.GetURLStatsRequest
+	(*AliasStats)(nil),              // 11: shortener.v1.AliasStats
+	(*URLStats)(nil),                // 12: shortener.v1.URLStats
+	(*GetURLStatsResponse)(nil),     // 13: shortener.v1.GetURLStatsResponse
+	(*ListURLsRequest)(nil),         // 14: shortener.v1.ListURLsRequest
+	(*ListURLsResponse)(nil),        // 15: shortener.v1.ListURLsResponse
+	(*DeleteURLRequest)(nil),        // 16: shortener.v1.DeleteURLRequest
+	(*DeleteURLResponse)(nil),       // 17: shortener.v1.DeleteURLResponse
+	nil,                             // 18: shortener.v1.URL.LanguageTargetsEntry
+	nil,                             // 19: shortener.v1.CreateURLRequest.LanguageTargetsEntry
+	(*timestamppb.Timestamp)(nil),   // 20: google.protobuf.Timestamp
+}
+var file_shortener_v1_shortener_proto_depIdxs = []int32{
+	20, // 0: shortener.v1.URL.created_at:type_name -> google.protobuf.Timestamp
+	20, // 1: shortener.v1.URL.expires_at:type_name -> google.protobuf.Timestamp
+	18, // 2: shortener.v1.URL.language_targets:type_name -> shortener.v1.URL.LanguageTargetsEntry
+	0,  // 3: shortener.v1.URL.open_graph:type_name -> shortener.v1.OpenGraph
+	20, // 4: shortener.v1.CreateURLRequest.expires_at:type_name -> google.protobuf.Timestamp
+	19, // 5: shortener.v1.CreateURLRequest.language_targets:type_name -> shortener.v1.CreateURLRequest.LanguageTargetsEntry
+	0,  // 6: shortener.v1.CreateURLRequest.open_graph:type_name -> shortener.v1.OpenGraph
+	1,  // 7: shortener.v1.CreateURLResponse.url:type_name -> shortener.v1.URL
+	2,  // 8: shortener.v1.BatchCreateURLsRequest.requests:type_name -> shortener.v1.CreateURLRequest
+	6,  // 9: shortener.v1.BatchCreateURLsResponse.results:type_name -> shortener.v1.BatchCreateURLsResult
+	1,  // 10: shortener.v1.BatchCreateURLsResult.url:type_name -> shortener.v1.URL
+	7,  // 11: shortener.v1.BatchCreateURLsResult.error:type_name -> shortener.v1.Error
+	1,  // 12: shortener.v1.GetURLResponse.url:type_name -> shortener.v1.URL
+	20, // 13: shortener.v1.URLStats.created_at:type_name -> google.protobuf.Timestamp
+	11, // 14: shortener.v1.URLStats.aliases:type_name -> shortener.v1.AliasStats
+	12, // 15: shortener.v1.GetURLStatsResponse.stats:type_name -> shortener.v1.URLStats
+	1,  // 16: shortener.v1.ListURLsResponse.urls:type_name -> shortener.v1.URL
+	2,  // 17: shortener.v1.URLService.CreateURL:input_type -> shortener.v1.CreateURLRequest
+	4,  // 18: shortener.v1.URLService.BatchCreateURLs:input_type -> shortener.v1.BatchCreateURLsRequest
+	8,  // 19: shortener.v1.URLService.GetURL:input_type -> shortener.v1.GetURLRequest
+	10, // 20: shortener.v1.URLService.GetURLStats:input_type -> shortener.v1.GetURLStatsRequest
+	14, // 21: shortener.v1.URLService.ListURLs:input_type -> shortener.v1.ListURLsRequest
+	16, // 22: shortener.v1.URLService.DeleteURL:input_type -> shortener.v1.DeleteURLRequest
+	3,  // 23: shortener.v1.URLService.CreateURL:output_type -> shortener.v1.CreateURLResponse
+	5,  // 24: shortener.v1.URLService.BatchCreateURLs:output_type -> shortener.v1.BatchCreateURLsResponse
+	9,  // 25: shortener.v1.URLService.GetURL:output_type -> shortener.v1.GetURLResponse
+	13, // 26: shortener.v1.URLService.GetURLStats:output_type -> shortener.v1.GetURLStatsResponse
+	15, // 27: shortener.v1.URLService.ListURLs:output_type -> shortener.v1.ListURLsResponse
+	17, // 28: shortener.v1.URLService.DeleteURL:output_type -> shortener.v1.DeleteURLResponse
+	23, // [23:29] is the sub-list for method output_type
+	17, // [17:23] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
+}
+
+func init() { file_shortener_v1_shortener_proto_init() }
+func file_shortener_v1_shortener_proto_init() {
+	if File_shortener_v1_shortener_proto != nil {
+		return
+	}
+	file_shortener_v1_shortener_proto_msgTypes[1].OneofWrappers = []any{}
+	file_shortener_v1_shortener_proto_msgTypes[2].OneofWrappers = []any{}
+	file_shortener_v1_shortener_proto_msgTypes[6].OneofWrappers = []any{
+		(*BatchCreateURLsResult_Url)(nil),
+		(*BatchCreateURLsResult_Error)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_shortener_v1_shortener_proto_rawDesc), len(file_shortener_v1_shortener_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   20,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_shortener_v1_shortener_proto_goTypes,
+		DependencyIndexes: file_shortener_v1_shortener_proto_depIdxs,
+		MessageInfos:      file_shortener_v1_shortener_proto_msgTypes,
+	}.Build()
+	File_shortener_v1_shortener_proto = out.File
+	file_shortener_v1_shortener_proto_goTypes = nil
+	file_shortener_v1_shortener_proto_depIdxs = nil
+}
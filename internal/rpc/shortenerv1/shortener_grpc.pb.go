@@ -0,0 +1,327 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: shortener/v1/shortener.proto
+
+package shortenerv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	URLService_CreateURL_FullMethodName       = "/shortener.v1.URLService/CreateURL"
+	URLService_BatchCreateURLs_FullMethodName = "/shortener.v1.URLService/BatchCreateURLs"
+	URLService_GetURL_FullMethodName          = "/shortener.v1.URLService/GetURL"
+	URLService_GetURLStats_FullMethodName     = "/shortener.v1.URLService/GetURLStats"
+	URLService_ListURLs_FullMethodName        = "/shortener.v1.URLService/ListURLs"
+	URLService_DeleteURL_FullMethodName       = "/shortener.v1.URLService/DeleteURL"
+)
+
+// URLServiceClient is the client API for URLService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// URLService exposes the link endpoints of the REST API to internal services.
+// Callers holding the admin API key send it as "authorization: Bearer <key>"
+// metadata.
+type URLServiceClient interface {
+	CreateURL(ctx context.Context, in *CreateURLRequest, opts ...grpc.CallOption) (*CreateURLResponse, error)
+	// BatchCreateURLs creates each link independently; one failing does not
+	// stop the others.
+	BatchCreateURLs(ctx context.Context, in *BatchCreateURLsRequest, opts ...grpc.CallOption) (*BatchCreateURLsResponse, error)
+	GetURL(ctx context.Context, in *GetURLRequest, opts ...grpc.CallOption) (*GetURLResponse, error)
+	GetURLStats(ctx context.Context, in *GetURLStatsRequest, opts ...grpc.CallOption) (*GetURLStatsResponse, error)
+	// ListURLs lists every link. It requires the admin API key.
+	ListURLs(ctx context.Context, in *ListURLsRequest, opts ...grpc.CallOption) (*ListURLsResponse, error)
+	// DeleteURL moves a link to the trash. It requires the admin API key.
+	DeleteURL(ctx context.Context, in *DeleteURLRequest, opts ...grpc.CallOption) (*DeleteURLResponse, error)
+}
+
+type uRLServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewURLServiceClient(cc grpc.ClientConnInterface) URLServiceClient {
+	return &uRLServiceClient{cc}
+}
+
+func (c *uRLServiceClient) CreateURL(ctx context.Context, in *CreateURLRequest, opts ...grpc.CallOption) (*CreateURLResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateURLResponse)
+	err := c.cc.Invoke(ctx, URLService_CreateURL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uRLServiceClient) BatchCreateURLs(ctx context.Context, in *BatchCreateURLsRequest, opts ...grpc.CallOption) (*BatchCreateURLsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchCreateURLsResponse)
+	err := c.cc.Invoke(ctx, URLService_BatchCreateURLs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uRLServiceClient) GetURL(ctx context.Context, in *GetURLRequest, opts ...grpc.CallOption) (*GetURLResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetURLResponse)
+	err := c.cc.Invoke(ctx, URLService_GetURL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uRLServiceClient) GetURLStats(ctx context.Context, in *GetURLStatsRequest, opts ...grpc.CallOption) (*GetURLStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetURLStatsResponse)
+	err := c.cc.Invoke(ctx, URLService_GetURLStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uRLServiceClient) ListURLs(ctx context.Context, in *ListURLsRequest, opts ...grpc.CallOption) (*ListURLsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListURLsResponse)
+	err := c.cc.Invoke(ctx, URLService_ListURLs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uRLServiceClient) DeleteURL(ctx context.Context, in *DeleteURLRequest, opts ...grpc.CallOption) (*DeleteURLResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteURLResponse)
+	err := c.cc.Invoke(ctx, URLService_DeleteURL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// URLServiceServer is the server API for URLService service.
+// All implementations must embed UnimplementedURLServiceServer
+// for forward compatibility.
+//
+// URLService exposes the link endpoints of the REST API to internal services.
+// Callers holding the admin API key send it as "authorization: Bearer <key>"
+// metadata.
+type URLServiceServer interface {
+	CreateURL(context.Context, *CreateURLRequest) (*CreateURLResponse, error)
+	// BatchCreateURLs creates each link independently; one failing does not
+	// stop the others.
+	BatchCreateURLs(context.Context, *BatchCreateURLsRequest) (*BatchCreateURLsResponse, error)
+	GetURL(context.Context, *GetURLRequest) (*GetURLResponse, error)
+	GetURLStats(context.Context, *GetURLStatsRequest) (*GetURLStatsResponse, error)
+	// ListURLs lists every link. It requires the admin API key.
+	ListURLs(context.Context, *ListURLsRequest) (*ListURLsResponse, error)
+	// DeleteURL moves a link to the trash. It requires the admin API key.
+	DeleteURL(context.Context, *DeleteURLRequest) (*DeleteURLResponse, error)
+	mustEmbedUnimplementedURLServiceServer()
+}
+
+// UnimplementedURLServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedURLServiceServer struct{}
+
+func (UnimplementedURLServiceServer) CreateURL(context.Context, *CreateURLRequest) (*CreateURLResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateURL not implemented")
+}
+func (UnimplementedURLServiceServer) BatchCreateURLs(context.Context, *BatchCreateURLsRequest) (*BatchCreateURLsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchCreateURLs not implemented")
+}
+func (UnimplementedURLServiceServer) GetURL(context.Context, *GetURLRequest) (*GetURLResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetURL not implemented")
+}
+func (UnimplementedURLServiceServer) GetURLStats(context.Context, *GetURLStatsRequest) (*GetURLStatsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetURLStats not implemented")
+}
+func (UnimplementedURLServiceServer) ListURLs(context.Context, *ListURLsRequest) (*ListURLsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListURLs not implemented")
+}
+func (UnimplementedURLServiceServer) DeleteURL(context.Context, *DeleteURLRequest) (*DeleteURLResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteURL not implemented")
+}
+func (UnimplementedURLServiceServer) mustEmbedUnimplementedURLServiceServer() {}
+func (UnimplementedURLServiceServer) testEmbeddedByValue()                    {}
+
+// UnsafeURLServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to URLServiceServer will
+// result in compilation errors.
+type UnsafeURLServiceServer interface {
+	mustEmbedUnimplementedURLServiceServer()
+}
+
+func RegisterURLServiceServer(s grpc.ServiceRegistrar, srv URLServiceServer) {
+	// If the following call panics, it indicates UnimplementedURLServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&URLService_ServiceDesc, srv)
+}
+
+func _URLService_CreateURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(URLServiceServer).CreateURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: URLService_CreateURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(URLServiceServer).CreateURL(ctx, req.(*CreateURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _URLService_BatchCreateURLs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateURLsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(URLServiceServer).BatchCreateURLs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: URLService_BatchCreateURLs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(URLServiceServer).BatchCreateURLs(ctx, req.(*BatchCreateURLsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _URLService_GetURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(URLServiceServer).GetURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: URLService_GetURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(URLServiceServer).GetURL(ctx, req.(*GetURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _URLService_GetURLStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetURLStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(URLServiceServer).GetURLStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: URLService_GetURLStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(URLServiceServer).GetURLStats(ctx, req.(*GetURLStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _URLService_ListURLs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListURLsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(URLServiceServer).ListURLs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: URLService_ListURLs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(URLServiceServer).ListURLs(ctx, req.(*ListURLsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _URLService_DeleteURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(URLServiceServer).DeleteURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: URLService_DeleteURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(URLServiceServer).DeleteURL(ctx, req.(*DeleteURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// URLService_ServiceDesc is the grpc.ServiceDesc for URLService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var URLService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "shortener.v1.URLService",
+	HandlerType: (*URLServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateURL",
+			Handler:    _URLService_CreateURL_Handler,
+		},
+		{
+			MethodName: "BatchCreateURLs",
+			Handler:    _URLService_BatchCreateURLs_Handler,
+		},
+		{
+			MethodName: "GetURL",
+			Handler:    _URLService_GetURL_Handler,
+		},
+		{
+			MethodName: "GetURLStats",
+			Handler:    _URLService_GetURLStats_Handler,
+		},
+		{
+			MethodName: "ListURLs",
+			Handler:    _URLService_ListURLs_Handler,
+		},
+		{
+			MethodName: "DeleteURL",
+			Handler:    _URLService_DeleteURL_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "shortener/v1/shortener.proto",
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ifaisalabid1/url-shortener/internal/model"
+)
+
+type actorKey struct{}
+
+// WithActor returns a context carrying the actor making the request.
+func WithActor(ctx context.Context, actor *model.Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// withSystemActor marks changes made through ctx as made by a background
+// job rather than a caller.
+func withSystemActor(ctx context.Context) context.Context {
+	return WithActor(ctx, &model.Actor{Name: model.ActorSystem, Trust: model.TrustTrusted})
+}
+
+// ActorFromContext returns the actor making the request, defaulting to an
+// anonymous one.
+func ActorFromContext(ctx context.Context) *model.Actor {
+	if actor, ok := ctx.Value(actorKey{}).(*model.Actor); ok {
+		return actor
+	}
+
+	return &model.Actor{Name: model.TrustAnonymous, Trust: model.TrustAnonymous}
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ifaisalabid1/url-shortener/internal/model"
+	"github.com/ifaisalabid1/url-shortener/internal/repository"
+)
+
+func (s *urlService) CreateAlias(ctx context.Context, shortCode string, req *model.CreateAliasRequest) (*model.AliasResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	url, err := s.urlRepo.GetByShortCode(ctx, DomainFromContext(ctx), shortCode)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.urlRepo.GetByShortCode(ctx, url.Domain, req.Alias)
+	if err == nil && existing != nil {
+		return nil, repository.ErrDuplicateCode
+	}
+
+	alias := &model.Alias{
+		ID:        uuid.New(),
+		URLID:     url.ID,
+		Domain:    url.Domain,
+		ShortCode: req.Alias,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if err := s.aliasRepo.Create(ctx, alias); err != nil {
+		return nil, fmt.Errorf("failed to create alias: %w", err)
+	}
+
+	return alias.ToResponse(s.baseURL), nil
+}
+
+func (s *urlService) ListAliases(ctx context.Context, shortCode string) ([]*model.AliasResponse, error) {
+	url, err := s.urlRepo.GetByShortCode(ctx, DomainFromContext(ctx), shortCode)
+	if err != nil {
+		return nil, err
+	}
+
+	aliases, err := s.aliasRepo.ListByURLID(ctx, url.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*model.AliasResponse, 0, len(aliases))
+	for _, alias := range aliases {
+		res = append(res, alias.ToResponse(s.baseURL))
+	}
+
+	return res, nil
+}
+
+// ListAliasesByURLIDs loads the aliases of several URLs at once, keyed by URL.
+func (s *urlService) ListAliasesByURLIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]*model.AliasResponse, error) {
+	aliases, err := s.aliasRepo.ListByURLIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[uuid.UUID][]*model.AliasResponse, len(ids))
+	for _, alias := range aliases {
+		res[alias.URLID] = append(res[alias.URLID], alias.ToResponse(s.baseURL))
+	}
+
+	return res, nil
+}
+
+func (s *urlService) DeleteAlias(ctx context.Context, shortCode, alias string) error {
+	url, err := s.urlRepo.GetByShortCode(ctx, DomainFromContext(ctx), shortCode)
+	if err != nil {
+		return err
+	}
+
+	if err := s.aliasRepo.Delete(ctx, url.ID, alias); err != nil {
+		return err
+	}
+
+	if err := s.cacheRepo.DeleteURL(ctx, url.Domain, alias); err != nil {
+		fmt.Printf("failed to evict alias from cache: %v\n", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ifaisalabid1/url-shortener/internal/model"
+	"github.com/ifaisalabid1/url-shortener/internal/repository"
+)
+
+type AuditService interface {
+	Record(ctx context.Context, entry *model.AuditEntry) error
+	List(ctx context.Context, from, to time.Time, limit, offset int) ([]*model.AuditEntry, error)
+	Export(ctx context.Context, from, to time.Time, fn func(*model.AuditEntry) error) error
+}
+
+type auditService struct {
+	auditRepo repository.AuditRepository
+}
+
+func NewAuditService(auditRepo repository.AuditRepository) AuditService {
+	return &auditService{auditRepo: auditRepo}
+}
+
+func (s *auditService) Record(ctx context.Context, entry *model.AuditEntry) error {
+	if entry.ID == uuid.Nil {
+		entry.ID = uuid.New()
+	}
+
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now().UTC()
+	}
+
+	return s.auditRepo.Create(ctx, entry)
+}
+
+func (s *auditService) List(ctx context.Context, from, to time.Time, limit, offset int) ([]*model.AuditEntry, error) {
+	return s.auditRepo.List(ctx, from, to, limit, offset)
+}
+
+func (s *auditService) Export(ctx context.Context, from, to time.Time, fn func(*model.AuditEntry) error) error {
+	return s.auditRepo.Each(ctx, from, to, fn)
+}
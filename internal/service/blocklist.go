@@ -0,0 +1,247 @@
+package service
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Blocklist file formats.
+const (
+	BlocklistDomains    = "domains"
+	BlocklistHosts      = "hosts"
+	BlocklistHashPrefix = "hashprefix"
+)
+
+// hostsFileNames are entries of a stock hosts file that are not blocks.
+var hostsFileNames = map[string]bool{
+	"localhost":             true,
+	"localhost.localdomain": true,
+	"local":                 true,
+	"broadcasthost":         true,
+	"ip6-localhost":         true,
+	"ip6-loopback":          true,
+	"ip6-localnet":          true,
+	"ip6-mcastprefix":       true,
+	"ip6-allnodes":          true,
+	"ip6-allrouters":        true,
+	"ip6-allhosts":          true,
+	"0.0.0.0":               true,
+}
+
+type BlocklistSource struct {
+	Format string
+	Path   string
+}
+
+// ParseBlocklistSource parses a "format:path" source, treating a bare path
+// as a plain domain list.
+func ParseBlocklistSource(source string) (BlocklistSource, error) {
+	format, path, ok := strings.Cut(source, ":")
+	if !ok {
+		return BlocklistSource{Format: BlocklistDomains, Path: source}, nil
+	}
+
+	switch format {
+	case BlocklistDomains, BlocklistHosts, BlocklistHashPrefix:
+		return BlocklistSource{Format: format, Path: path}, nil
+	default:
+		return BlocklistSource{}, fmt.Errorf("unknown blocklist format %q", format)
+	}
+}
+
+// Blocklist matches destinations against known-bad domains and Safe
+// Browsing-style SHA-256 hash prefixes loaded from local files.
+type Blocklist struct {
+	sources []BlocklistSource
+
+	mu            sync.RWMutex
+	domains       map[string]struct{}
+	hashPrefixes  map[string]struct{}
+	prefixLengths map[int]struct{}
+}
+
+func NewBlocklist(sources []BlocklistSource) *Blocklist {
+	return &Blocklist{
+		sources:       sources,
+		domains:       map[string]struct{}{},
+		hashPrefixes:  map[string]struct{}{},
+		prefixLengths: map[int]struct{}{},
+	}
+}
+
+// Reload re-reads every source. The current lists are kept if any source
+// fails to load.
+func (b *Blocklist) Reload() error {
+	domains := map[string]struct{}{}
+	hashPrefixes := map[string]struct{}{}
+	prefixLengths := map[int]struct{}{}
+
+	for _, source := range b.sources {
+		err := readLines(source.Path, func(line string) error {
+			switch source.Format {
+			case BlocklistHosts:
+				fields := strings.Fields(line)
+				for _, name := range fields[min(1, len(fields)-1):] {
+					if name = strings.ToLower(name); !hostsFileNames[name] {
+						domains[name] = struct{}{}
+					}
+				}
+			case BlocklistHashPrefix:
+				prefix, err := hex.DecodeString(line)
+				if err != nil || len(prefix) < 4 || len(prefix) > sha256.Size {
+					return fmt.Errorf("invalid hash prefix %q", line)
+				}
+
+				hashPrefixes[string(prefix)] = struct{}{}
+				prefixLengths[len(prefix)] = struct{}{}
+			default:
+				domain := strings.TrimPrefix(strings.TrimPrefix(strings.ToLower(line), "*"), ".")
+				domains[domain] = struct{}{}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to load blocklist %s: %w", source.Path, err)
+		}
+	}
+
+	b.mu.Lock()
+	b.domains = domains
+	b.hashPrefixes = hashPrefixes
+	b.prefixLengths = prefixLengths
+	b.mu.Unlock()
+
+	return nil
+}
+
+// Size returns the number of loaded domains and hash prefixes.
+func (b *Blocklist) Size() (domains, hashPrefixes int) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return len(b.domains), len(b.hashPrefixes)
+}
+
+// Check reports whether a destination is blocklisted, and why.
+func (b *Blocklist) Check(destination string) (string, bool) {
+	u, err := url.Parse(destination)
+	if err != nil || u.Hostname() == "" {
+		return "", false
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, suffix := range hostSuffixes(host) {
+		if _, ok := b.domains[suffix]; ok {
+			return fmt.Sprintf("domain %q is on a blocklist", suffix), true
+		}
+	}
+
+	if len(b.hashPrefixes) == 0 {
+		return "", false
+	}
+
+	for _, expression := range urlExpressions(host, u) {
+		hash := sha256.Sum256([]byte(expression))
+
+		for length := range b.prefixLengths {
+			if _, ok := b.hashPrefixes[string(hash[:length])]; ok {
+				return fmt.Sprintf("%q matches a blocklist hash prefix", expression), true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// hostSuffixes returns the host followed by its parent domains, excluding the
+// bare top-level domain.
+func hostSuffixes(host string) []string {
+	suffixes := []string{host}
+	if _, ok := parseIPLiteral(host); ok {
+		return suffixes
+	}
+
+	labels := strings.Split(host, ".")
+	for i := 1; i < len(labels)-1; i++ {
+		suffixes = append(suffixes, strings.Join(labels[i:], "."))
+	}
+
+	return suffixes
+}
+
+// urlExpressions builds the host-suffix/path-prefix combinations that Safe
+// Browsing hashes for a URL.
+func urlExpressions(host string, u *url.URL) []string {
+	hosts := []string{host}
+	if _, ok := parseIPLiteral(host); !ok {
+		labels := strings.Split(host, ".")
+		for i := max(len(labels)-5, 1); i < len(labels)-1 && len(hosts) < 5; i++ {
+			hosts = append(hosts, strings.Join(labels[i:], "."))
+		}
+	}
+
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+
+	paths := []string{path}
+	if u.RawQuery != "" {
+		paths = append([]string{path + "?" + u.RawQuery}, paths...)
+	}
+
+	prefix := "/"
+	paths = append(paths, prefix)
+	for _, segment := range strings.Split(strings.Trim(path, "/"), "/") {
+		if len(paths) >= 6 || segment == "" {
+			break
+		}
+
+		prefix += segment + "/"
+		if prefix != path {
+			paths = append(paths, prefix)
+		}
+	}
+
+	var expressions []string
+	for _, h := range hosts {
+		for _, p := range paths {
+			expressions = append(expressions, h+p)
+		}
+	}
+
+	return expressions
+}
+
+func readLines(path string, fn func(line string) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		if line = strings.TrimSpace(line); line == "" {
+			continue
+		}
+
+		if err := fn(line); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
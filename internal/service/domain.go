@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ifaisalabid1/url-shortener/internal/model"
+	"github.com/ifaisalabid1/url-shortener/internal/repository"
+)
+
+var ErrUnknownDomain = errors.New("unknown short domain")
+
+type domainKey struct{}
+
+// WithDomain returns a context carrying the short domain a request is for.
+func WithDomain(ctx context.Context, domain string) context.Context {
+	return context.WithValue(ctx, domainKey{}, domain)
+}
+
+// DomainFromContext returns the short domain a request is for, defaulting to
+// the empty default domain.
+func DomainFromContext(ctx context.Context) string {
+	domain, _ := ctx.Value(domainKey{}).(string)
+	return domain
+}
+
+// NormalizeHost lower-cases a host and strips any port and trailing dot.
+func NormalizeHost(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	return strings.TrimSuffix(strings.ToLower(host), ".")
+}
+
+// DomainService manages the branded short domains. Registered hosts are kept
+// in memory so requests can be matched to a domain without a query.
+//
+// Domains are global rather than registered per workspace: the API has no
+// workspaces or accounts, only anonymous callers and the single admin API
+// key, so there is nothing to own a domain. Only the admin key can manage
+// them. Scoping belongs here and in domain_repo.go once workspaces exist.
+type DomainService interface {
+	Has(host string) bool
+	Fallbacks(host string) model.DomainFallbacks
+	Reload(ctx context.Context) error
+	List(ctx context.Context) ([]*model.ShortDomain, error)
+	Register(ctx context.Context, req *model.CreateShortDomainRequest) (*model.ShortDomain, error)
+	UpdateFallbacks(ctx context.Context, host string, req *model.DomainFallbacks) (*model.ShortDomain, error)
+	Remove(ctx context.Context, host string) error
+}
+
+type domainService struct {
+	domainRepo repository.DomainRepository
+
+	// defaults are the fallbacks of the default domain, which has no row.
+	defaults model.DomainFallbacks
+
+	mu    sync.RWMutex
+	hosts map[string]*model.ShortDomain
+}
+
+func NewDomainService(domainRepo repository.DomainRepository, defaults model.DomainFallbacks) DomainService {
+	return &domainService{
+		domainRepo: domainRepo,
+		defaults:   defaults,
+		hosts:      map[string]*model.ShortDomain{},
+	}
+}
+
+func (s *domainService) Has(host string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.hosts[host]
+	return ok
+}
+
+func (s *domainService) Fallbacks(host string) model.DomainFallbacks {
+	if host == "" {
+		return s.defaults
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if domain, ok := s.hosts[host]; ok {
+		return domain.DomainFallbacks
+	}
+
+	return model.DomainFallbacks{}
+}
+
+// Reload replaces the registered hosts with those in the database, picking up
+// changes made through other instances.
+func (s *domainService) Reload(ctx context.Context) error {
+	domains, err := s.domainRepo.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	hosts := make(map[string]*model.ShortDomain, len(domains))
+	for _, domain := range domains {
+		hosts[domain.Host] = domain
+	}
+
+	s.mu.Lock()
+	s.hosts = hosts
+	s.mu.Unlock()
+
+	return nil
+}
+
+func (s *domainService) List(ctx context.Context) ([]*model.ShortDomain, error) {
+	return s.domainRepo.List(ctx)
+}
+
+func (s *domainService) Register(ctx context.Context, req *model.CreateShortDomainRequest) (*model.ShortDomain, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	domain := &model.ShortDomain{
+		Host:      NormalizeHost(req.Host),
+		CreatedAt: time.Now().UTC(),
+
+		DomainFallbacks: req.DomainFallbacks,
+	}
+
+	if err := s.domainRepo.Create(ctx, domain); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.hosts[domain.Host] = domain
+	s.mu.Unlock()
+
+	return domain, nil
+}
+
+func (s *domainService) UpdateFallbacks(ctx context.Context, host string, req *model.DomainFallbacks) (*model.ShortDomain, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	host = NormalizeHost(host)
+
+	if err := s.domainRepo.UpdateFallbacks(ctx, host, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.Reload(ctx); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	domain, ok := s.hosts[host]
+	if !ok {
+		return nil, repository.ErrShortDomainNotFound
+	}
+
+	return domain, nil
+}
+
+func (s *domainService) Remove(ctx context.Context, host string) error {
+	host = NormalizeHost(host)
+
+	if err := s.domainRepo.Delete(ctx, host); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	delete(s.hosts, host)
+	s.mu.Unlock()
+
+	return nil
+}
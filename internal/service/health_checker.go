@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ifaisalabid1/url-shortener/internal/model"
+	"github.com/ifaisalabid1/url-shortener/internal/repository"
+)
+
+const healthCheckUserAgent = "url-shortener-health-checker/1.0"
+
+// HealthChecker probes link destinations and records whether they still
+// resolve.
+type HealthChecker struct {
+	urlRepo     repository.URLRepository
+	client      *http.Client
+	concurrency int
+	hostDelay   time.Duration
+	batchSize   int
+	maxAge      time.Duration
+}
+
+// NewHealthChecker returns a HealthChecker that runs at most concurrency
+// hosts at once, waits hostDelay between requests to the same host, and
+// rechecks links whose last check is older than maxAge.
+func NewHealthChecker(urlRepo repository.URLRepository, client *http.Client, concurrency int, hostDelay time.Duration, batchSize int, maxAge time.Duration) *HealthChecker {
+	return &HealthChecker{
+		urlRepo:     urlRepo,
+		client:      client,
+		concurrency: max(concurrency, 1),
+		hostDelay:   hostDelay,
+		batchSize:   batchSize,
+		maxAge:      maxAge,
+	}
+}
+
+// NewHealthCheckClient returns an HTTP client that refuses to connect to
+// addresses the destination policy would block, so redirects and DNS cannot
+// steer the checker into the internal network.
+func NewHealthCheckClient(timeout time.Duration, policy *DestinationPolicy) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			ip, err := netip.ParseAddr(host)
+			if err != nil {
+				return err
+			}
+
+			if policy.blockPrivateIPs && isInternalIP(ip) {
+				return fmt.Errorf("%w: address %s is in a private or reserved range", ErrDestinationRejected, ip)
+			}
+
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	transport.Proxy = nil
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+
+			return policy.Check(req.URL.String())
+		},
+	}
+}
+
+// CheckDue checks a batch of links that have not been checked recently,
+// returning how many were checked and how many were found broken.
+func (c *HealthChecker) CheckDue(ctx context.Context) (checked, broken int, err error) {
+	urls, err := c.urlRepo.ListDueForHealthCheck(ctx, time.Now().UTC().Add(-c.maxAge), c.batchSize)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	byHost := map[string][]*model.URL{}
+	for _, u := range urls {
+		host := u.NormalizedURL
+		if parsed, err := url.Parse(u.NormalizedURL); err == nil {
+			host = parsed.Host
+		}
+
+		byHost[host] = append(byHost[host], u)
+	}
+
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, c.concurrency)
+	)
+
+	for _, hostURLs := range byHost {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			for i, u := range hostURLs {
+				if i > 0 {
+					select {
+					case <-ctx.Done():
+						return
+					case <-time.After(c.hostDelay):
+					}
+				}
+
+				health := c.Check(ctx, u.OriginalURL)
+
+				if err := c.urlRepo.UpdateHealth(ctx, u.ID, health); err != nil {
+					fmt.Printf("failed to record url health: %v\n", err)
+					continue
+				}
+
+				mu.Lock()
+				checked++
+				if health.Broken {
+					broken++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return checked, broken, ctx.Err()
+}
+
+// Check probes a single destination with HEAD, falling back to GET for
+// servers that do not support HEAD.
+func (c *HealthChecker) Check(ctx context.Context, destination string) *model.LinkHealth {
+	start := time.Now()
+
+	resp, err := c.do(ctx, http.MethodHead, destination)
+	if err == nil && (resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusNotImplemented) {
+		resp.Body.Close()
+
+		start = time.Now()
+		resp, err = c.do(ctx, http.MethodGet, destination)
+	}
+
+	health := &model.LinkHealth{
+		LatencyMS: time.Since(start).Milliseconds(),
+		CheckedAt: time.Now().UTC(),
+	}
+
+	if err != nil {
+		health.Error = err.Error()
+		health.Broken = true
+		return health
+	}
+	defer resp.Body.Close()
+
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	health.StatusCode = resp.StatusCode
+	health.FinalURL = resp.Request.URL.String()
+	health.Broken = resp.StatusCode >= 400 && resp.StatusCode != http.StatusTooManyRequests
+
+	return health
+}
+
+func (c *HealthChecker) do(ctx context.Context, method, destination string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, destination, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", healthCheckUserAgent)
+
+	return c.client.Do(req)
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestHealthCheckFallsBackToGet(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		methods []string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		methods = append(methods, r.Method)
+		mu.Unlock()
+
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	checker := NewHealthChecker(nil, NewHealthCheckClient(time.Second, testPolicy()), 1, 0, 10, time.Hour)
+
+	health := checker.Check(context.Background(), srv.URL)
+
+	if health.Broken || health.StatusCode != http.StatusOK {
+		t.Errorf("Check() = status %d, broken %v; want 200, not broken", health.StatusCode, health.Broken)
+	}
+
+	if want := []string{http.MethodHead, http.MethodGet}; !slices.Equal(methods, want) {
+		t.Errorf("requests = %v, want %v", methods, want)
+	}
+}
+
+func TestHealthCheckClassifiesStatus(t *testing.T) {
+	tests := []struct {
+		status     int
+		wantBroken bool
+	}{
+		{http.StatusOK, false},
+		{http.StatusNoContent, false},
+		{http.StatusTooManyRequests, false},
+		{http.StatusNotFound, true},
+		{http.StatusGone, true},
+		{http.StatusInternalServerError, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			checker := NewHealthChecker(nil, NewHealthCheckClient(time.Second, testPolicy()), 1, 0, 10, time.Hour)
+
+			health := checker.Check(context.Background(), srv.URL)
+
+			if health.StatusCode != tt.status || health.Broken != tt.wantBroken {
+				t.Errorf("Check() = status %d, broken %v; want %d, broken %v", health.StatusCode, health.Broken, tt.status, tt.wantBroken)
+			}
+		})
+	}
+}
+
+func TestHealthCheckFollowsRedirects(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/old" {
+			http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	checker := NewHealthChecker(nil, NewHealthCheckClient(time.Second, testPolicy()), 1, 0, 10, time.Hour)
+
+	health := checker.Check(context.Background(), srv.URL+"/old")
+
+	if health.Broken || health.FinalURL != srv.URL+"/new" {
+		t.Errorf("Check() = final URL %q, broken %v; want %q, not broken", health.FinalURL, health.Broken, srv.URL+"/new")
+	}
+}
+
+func TestHealthCheckRejectsRedirectOutsidePolicy(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://denied.example/", http.StatusFound)
+	}))
+	defer srv.Close()
+
+	checker := NewHealthChecker(nil, NewHealthCheckClient(time.Second, testPolicy("denied.example")), 1, 0, 10, time.Hour)
+
+	health := checker.Check(context.Background(), srv.URL)
+
+	if !health.Broken || !strings.Contains(health.Error, "denied.example") {
+		t.Errorf("Check() = broken %v, error %q; want broken by the policy", health.Broken, health.Error)
+	}
+}
+
+func TestHealthCheckRefusesPrivateAddresses(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("checker connected to a loopback address")
+	}))
+	defer srv.Close()
+
+	policy := NewDestinationPolicy([]string{"http", "https"}, nil, nil, true)
+	checker := NewHealthChecker(nil, NewHealthCheckClient(time.Second, policy), 1, 0, 10, time.Hour)
+
+	health := checker.Check(context.Background(), srv.URL)
+
+	if !health.Broken || !strings.Contains(health.Error, "private or reserved") {
+		t.Errorf("Check() = broken %v, error %q; want the address refused", health.Broken, health.Error)
+	}
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ifaisalabid1/url-shortener/internal/model"
+)
+
+var ErrNothingToRevert = errors.New("history entry has no state to revert to")
+
+func (s *urlService) GetURLHistory(ctx context.Context, id uuid.UUID) ([]*model.HistoryEntry, error) {
+	return s.historyRepo.ListByURLID(ctx, id)
+}
+
+// ListHistoryByURLIDs loads the history of several URLs at once, keyed by URL.
+func (s *urlService) ListHistoryByURLIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]*model.HistoryEntry, error) {
+	entries, err := s.historyRepo.ListByURLIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[uuid.UUID][]*model.HistoryEntry, len(ids))
+	for _, entry := range entries {
+		res[entry.URLID] = append(res[entry.URLID], entry)
+	}
+
+	return res, nil
+}
+
+// RevertURL restores the state a link had after the given version, recording
+// the revert as a new version.
+func (s *urlService) RevertURL(ctx context.Context, id uuid.UUID, version int) (*model.URLResponse, error) {
+	entry, err := s.historyRepo.Get(ctx, id, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.NewValue == nil {
+		return nil, ErrNothingToRevert
+	}
+
+	url, err := s.urlRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := url.Snapshot()
+	target := entry.NewValue
+
+	normalizedURL, err := s.prepareDestination(ctx, target.OriginalURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkLanguageTargets(ctx, target.LanguageTargets); err != nil {
+		return nil, err
+	}
+
+	url.OriginalURL = target.OriginalURL
+	url.NormalizedURL = normalizedURL
+	url.URLHash = hashURL(normalizedURL)
+	url.ExpiresAt = target.ExpiresAt
+	url.LanguageTargets = target.LanguageTargets
+	url.OpenGraph = target.OpenGraph
+	url.Interstitial = target.Interstitial
+	url.Folder = target.Folder
+	url.Tags = target.Tags
+	url.UpdatedAt = time.Now().UTC()
+
+	history := newHistoryEntry(ctx, url.ID, model.HistoryRevert, before, url.Snapshot())
+
+	if err := s.urlRepo.Update(ctx, url, history); err != nil {
+		return nil, fmt.Errorf("failed to revert url: %w", err)
+	}
+
+	evictURL(ctx, s.cacheRepo, s.aliasRepo, url)
+
+	res := url.ToResponse(s.baseURL)
+	s.publish(ctx, url.Creator, model.EventLinkUpdated, res)
+
+	return res, nil
+}
+
+// newHistoryEntry builds the history entry for a change made by the actor in
+// ctx. The repository saves it in the same transaction as the change.
+func newHistoryEntry(ctx context.Context, urlID uuid.UUID, action string, oldValue, newValue *model.URLSnapshot) *model.HistoryEntry {
+	actor := ActorFromContext(ctx)
+
+	return &model.HistoryEntry{
+		ID:        uuid.New(),
+		URLID:     urlID,
+		Action:    action,
+		Actor:     actor.Name,
+		ActorIP:   actor.IP,
+		OldValue:  oldValue,
+		NewValue:  newValue,
+		CreatedAt: time.Now().UTC(),
+	}
+}
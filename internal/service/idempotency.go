@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ifaisalabid1/url-shortener/internal/model"
+	"github.com/ifaisalabid1/url-shortener/internal/repository"
+)
+
+var (
+	ErrIdempotencyKeyReused   = errors.New("idempotency key was already used with a different request")
+	ErrIdempotencyKeyInFlight = errors.New("a request with this idempotency key is still in progress")
+)
+
+// idempotencyLockTTL bounds how long a key stays claimed by a request that
+// never completes, e.g. because the server crashed while handling it.
+const idempotencyLockTTL = 2 * time.Minute
+
+type IdempotencyService interface {
+	// Begin claims key for a request with the given fingerprint. It returns
+	// the stored response when an identical request already completed.
+	Begin(ctx context.Context, key, fingerprint string) (*model.IdempotentResponse, error)
+	Complete(ctx context.Context, key string, res *model.IdempotentResponse) error
+	Release(ctx context.Context, key string) error
+}
+
+type idempotencyService struct {
+	cacheRepo repository.CacheRepository
+	window    time.Duration
+}
+
+func NewIdempotencyService(cacheRepo repository.CacheRepository, window time.Duration) IdempotencyService {
+	return &idempotencyService{cacheRepo: cacheRepo, window: window}
+}
+
+func (s *idempotencyService) Begin(ctx context.Context, key, fingerprint string) (*model.IdempotentResponse, error) {
+	claimed, err := s.cacheRepo.ReserveIdempotencyKey(ctx, key, &model.IdempotentResponse{Fingerprint: fingerprint}, idempotencyLockTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	if claimed {
+		return nil, nil
+	}
+
+	stored, err := s.cacheRepo.GetIdempotencyKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case stored == nil:
+		// The key expired between the two calls; let the client retry.
+		return nil, ErrIdempotencyKeyInFlight
+	case stored.Fingerprint != fingerprint:
+		return nil, ErrIdempotencyKeyReused
+	case !stored.Completed:
+		return nil, ErrIdempotencyKeyInFlight
+	}
+
+	return stored, nil
+}
+
+func (s *idempotencyService) Complete(ctx context.Context, key string, res *model.IdempotentResponse) error {
+	res.Completed = true
+
+	return s.cacheRepo.SetIdempotencyKey(ctx, key, res, s.window)
+}
+
+func (s *idempotencyService) Release(ctx context.Context, key string) error {
+	return s.cacheRepo.DeleteIdempotencyKey(ctx, key)
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"sort"
+
+	"github.com/ifaisalabid1/url-shortener/internal/model"
+	"golang.org/x/text/language"
+)
+
+// selectDestination negotiates the Accept-Language header against the link's
+// language targets, falling back to the original URL when nothing matches.
+func selectDestination(url *model.URL, acceptLanguage string) string {
+	if len(url.LanguageTargets) == 0 || acceptLanguage == "" {
+		return url.OriginalURL
+	}
+
+	preferred, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(preferred) == 0 {
+		return url.OriginalURL
+	}
+
+	keys := make([]string, 0, len(url.LanguageTargets))
+	for key := range url.LanguageTargets {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// The matcher returns its first entry when nothing matches, so the
+	// undetermined tag stands in for the default destination.
+	supported := []language.Tag{language.Und}
+	for _, key := range keys {
+		supported = append(supported, language.Make(key))
+	}
+
+	_, index, confidence := language.NewMatcher(supported).Match(preferred...)
+	if index == 0 || confidence == language.No {
+		return url.OriginalURL
+	}
+
+	return url.LanguageTargets[keys[index-1]]
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/ifaisalabid1/url-shortener/internal/model"
+)
+
+// LiveClicks streams a link's clicks as they happen on any instance. With a
+// lastEventID, recent clicks after it are sent first so a reconnecting
+// client misses nothing still held in the recent clicks buffer. The channel
+// is closed once ctx is done or the subscription drops.
+func (s *urlService) LiveClicks(ctx context.Context, shortCode string, lastEventID int64) (<-chan *model.ClickEvent, error) {
+	url, err := s.urlRepo.GetByShortCode(ctx, DomainFromContext(ctx), shortCode)
+	if err != nil {
+		return nil, err
+	}
+
+	// Subscribe before reading the backlog so no click falls between them.
+	live, err := s.cacheRepo.SubscribeClicks(ctx, url.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var backlog []*model.ClickEvent
+	if lastEventID > 0 {
+		recent, err := s.cacheRepo.RecentClicks(ctx, url.ID)
+		if err != nil {
+			fmt.Printf("failed to get recent clicks: %v\n", err)
+		}
+
+		for _, event := range recent {
+			if event.Clicks > lastEventID {
+				backlog = append(backlog, event)
+			}
+		}
+
+		slices.SortFunc(backlog, func(a, b *model.ClickEvent) int {
+			return cmp.Compare(a.Clicks, b.Clicks)
+		})
+	}
+
+	events := make(chan *model.ClickEvent)
+
+	go func() {
+		defer close(events)
+
+		// Clicks up to here were either replayed or seen before the
+		// reconnect, so copies arriving live are dropped.
+		seen := lastEventID
+
+		for _, event := range backlog {
+			select {
+			case events <- event:
+				seen = event.Clicks
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		for event := range live {
+			if event.Clicks <= seen {
+				continue
+			}
+
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ifaisalabid1/url-shortener/internal/model"
+	"github.com/ifaisalabid1/url-shortener/internal/repository"
+)
+
+type ModerationService interface {
+	ReportURL(ctx context.Context, shortCode, reporterIP string, req *model.CreateReportRequest) (*model.Report, error)
+	ListReports(ctx context.Context, status string) ([]*model.Report, error)
+	ResolveReport(ctx context.Context, id uuid.UUID, req *model.ResolveReportRequest) (*model.Report, error)
+	SetURLStatus(ctx context.Context, shortCode string, req *model.SetURLStatusRequest) (*model.URLResponse, error)
+	BanDomain(ctx context.Context, req *model.BanDomainRequest) (*model.BanDomainResponse, error)
+	UnbanDomain(ctx context.Context, domain string) error
+	ListBannedDomains(ctx context.Context) ([]*model.BannedDomain, error)
+}
+
+type moderationService struct {
+	urlRepo        repository.URLRepository
+	aliasRepo      repository.AliasRepository
+	moderationRepo repository.ModerationRepository
+	cacheRepo      repository.CacheRepository
+	baseURL        string
+}
+
+func NewModerationService(urlRepo repository.URLRepository, aliasRepo repository.AliasRepository, moderationRepo repository.ModerationRepository, cacheRepo repository.CacheRepository, baseURL string) ModerationService {
+	return &moderationService{
+		urlRepo,
+		aliasRepo,
+		moderationRepo,
+		cacheRepo,
+		baseURL,
+	}
+}
+
+func (s *moderationService) ReportURL(ctx context.Context, shortCode, reporterIP string, req *model.CreateReportRequest) (*model.Report, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	url, err := s.urlRepo.GetByShortCode(ctx, DomainFromContext(ctx), shortCode)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &model.Report{
+		ID:         uuid.New(),
+		URLID:      url.ID,
+		ShortCode:  shortCode,
+		Reason:     req.Reason,
+		Details:    req.Details,
+		ReporterIP: reporterIP,
+		Status:     model.ReportStatusOpen,
+		CreatedAt:  time.Now().UTC(),
+	}
+
+	if err := s.moderationRepo.CreateReport(ctx, report); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func (s *moderationService) ListReports(ctx context.Context, status string) ([]*model.Report, error) {
+	return s.moderationRepo.ListReports(ctx, status)
+}
+
+func (s *moderationService) ResolveReport(ctx context.Context, id uuid.UUID, req *model.ResolveReportRequest) (*model.Report, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	report, err := s.moderationRepo.GetReport(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	report.Status = req.Status
+	report.ResolvedAt = &now
+
+	if err := s.moderationRepo.UpdateReport(ctx, report); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func (s *moderationService) SetURLStatus(ctx context.Context, shortCode string, req *model.SetURLStatusRequest) (*model.URLResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	url, err := s.urlRepo.GetByShortCode(ctx, DomainFromContext(ctx), shortCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.urlRepo.SetStatus(ctx, url.ID, req.Status, req.Reason); err != nil {
+		return nil, err
+	}
+
+	url.Status = req.Status
+	url.StatusReason = req.Reason
+
+	evictURL(ctx, s.cacheRepo, s.aliasRepo, url)
+
+	return url.ToResponse(s.baseURL), nil
+}
+
+// BanDomain bans a domain and its subdomains for new links and takes down
+// every existing link pointing at them.
+func (s *moderationService) BanDomain(ctx context.Context, req *model.BanDomainRequest) (*model.BanDomainResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	domain := &model.BannedDomain{
+		Domain:    strings.TrimSuffix(strings.ToLower(req.Domain), "."),
+		Reason:    req.Reason,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if err := s.moderationRepo.BanDomain(ctx, domain); err != nil {
+		return nil, err
+	}
+
+	links, err := s.urlRepo.BanByDomain(ctx, domain.Domain, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	evictLinks(ctx, s.cacheRepo, links)
+
+	return &model.BanDomainResponse{
+		Domain:      domain,
+		BannedCodes: len(links),
+	}, nil
+}
+
+func (s *moderationService) UnbanDomain(ctx context.Context, domain string) error {
+	return s.moderationRepo.UnbanDomain(ctx, strings.ToLower(domain))
+}
+
+func (s *moderationService) ListBannedDomains(ctx context.Context) ([]*model.BannedDomain, error) {
+	return s.moderationRepo.ListBannedDomains(ctx)
+}
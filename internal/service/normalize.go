@@ -0,0 +1,160 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+var ErrInvalidURL = errors.New("invalid url")
+
+var defaultPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+	"ftp":   "21",
+}
+
+// Normalizer canonicalizes destinations so equivalent URLs compare equal.
+type Normalizer struct {
+	sortQuery   bool
+	stripParams []string
+}
+
+// NewNormalizer returns a Normalizer. Entries in stripParams ending in "*"
+// match any query parameter with that prefix.
+func NewNormalizer(sortQuery bool, stripParams []string) *Normalizer {
+	return &Normalizer{
+		sortQuery:   sortQuery,
+		stripParams: stripParams,
+	}
+}
+
+func (n *Normalizer) Normalize(rawURL string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidURL, err)
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+
+	if u.Host != "" {
+		host, err := normalizeHost(u.Scheme, u.Hostname(), u.Port())
+		if err != nil {
+			return "", err
+		}
+
+		u.Host = host
+	}
+
+	if u.Opaque == "" {
+		escaped := cleanPath(u.EscapedPath())
+
+		u.Path, err = url.PathUnescape(escaped)
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", ErrInvalidURL, err)
+		}
+
+		u.RawPath = escaped
+	}
+
+	u.RawQuery = n.normalizeQuery(u.RawQuery)
+	u.ForceQuery = false
+
+	return u.String(), nil
+}
+
+func normalizeHost(scheme, hostname, port string) (string, error) {
+	hostname = strings.TrimSuffix(strings.ToLower(hostname), ".")
+
+	if net.ParseIP(hostname) == nil {
+		ascii, err := idna.Lookup.ToASCII(hostname)
+		if err != nil {
+			return "", fmt.Errorf("%w: invalid host %q: %v", ErrInvalidURL, hostname, err)
+		}
+
+		hostname = ascii
+	}
+
+	if port == defaultPorts[scheme] {
+		port = ""
+	}
+
+	if port != "" {
+		return net.JoinHostPort(hostname, port), nil
+	}
+
+	if strings.Contains(hostname, ":") {
+		return "[" + hostname + "]", nil
+	}
+
+	return hostname, nil
+}
+
+// cleanPath resolves dot segments and duplicate slashes while preserving a
+// trailing slash, which servers commonly treat as significant.
+func cleanPath(p string) string {
+	if p == "" {
+		return "/"
+	}
+
+	cleaned := path.Clean(p)
+	if !strings.HasPrefix(cleaned, "/") {
+		cleaned = "/" + cleaned
+	}
+
+	if strings.HasSuffix(p, "/") && cleaned != "/" {
+		cleaned += "/"
+	}
+
+	return cleaned
+}
+
+func (n *Normalizer) normalizeQuery(rawQuery string) string {
+	var params []string
+
+	for param := range strings.SplitSeq(rawQuery, "&") {
+		param = strings.TrimSpace(param)
+
+		key, _, _ := strings.Cut(param, "=")
+		if key == "" || n.stripped(key) {
+			continue
+		}
+
+		params = append(params, param)
+	}
+
+	if n.sortQuery {
+		sort.SliceStable(params, func(i, j int) bool {
+			ki, _, _ := strings.Cut(params[i], "=")
+			kj, _, _ := strings.Cut(params[j], "=")
+			return ki < kj
+		})
+	}
+
+	return strings.Join(params, "&")
+}
+
+func (n *Normalizer) stripped(key string) bool {
+	key, err := url.QueryUnescape(key)
+	if err != nil {
+		return false
+	}
+
+	for _, param := range n.stripParams {
+		if prefix, ok := strings.CutSuffix(param, "*"); ok {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+		} else if key == param {
+			return true
+		}
+	}
+
+	return false
+}
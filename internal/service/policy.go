@@ -0,0 +1,167 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+var ErrDestinationRejected = errors.New("destination rejected")
+
+// blockedPrefixes are ranges outside net.IP's private and loopback helpers
+// that still must not be reachable through a redirect.
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// DestinationPolicy decides which destinations links may point at.
+type DestinationPolicy struct {
+	allowedSchemes  map[string]bool
+	allowedDomains  []string
+	deniedDomains   []string
+	blockPrivateIPs bool
+}
+
+// NewDestinationPolicy returns a DestinationPolicy. Domain patterns are
+// matched exactly, or against any subdomain when written as "*.example.com".
+// An empty allow list permits every domain that is not denied.
+func NewDestinationPolicy(allowedSchemes, allowedDomains, deniedDomains []string, blockPrivateIPs bool) *DestinationPolicy {
+	schemes := make(map[string]bool, len(allowedSchemes))
+	for _, scheme := range allowedSchemes {
+		schemes[strings.ToLower(scheme)] = true
+	}
+
+	return &DestinationPolicy{
+		allowedSchemes:  schemes,
+		allowedDomains:  lowerAll(allowedDomains),
+		deniedDomains:   lowerAll(deniedDomains),
+		blockPrivateIPs: blockPrivateIPs,
+	}
+}
+
+// Check validates a normalized destination, describing the reason for any
+// rejection in the returned error.
+func (p *DestinationPolicy) Check(destination string) error {
+	u, err := url.Parse(destination)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidURL, err)
+	}
+
+	if !p.allowedSchemes[u.Scheme] {
+		return fmt.Errorf("%w: scheme %q is not allowed", ErrDestinationRejected, u.Scheme)
+	}
+
+	host := u.Hostname()
+	if host == "" {
+		return fmt.Errorf("%w: a host is required", ErrDestinationRejected)
+	}
+
+	if ip, ok := parseIPLiteral(host); ok {
+		if p.blockPrivateIPs && isInternalIP(ip) {
+			return fmt.Errorf("%w: address %s is in a private or reserved range", ErrDestinationRejected, ip)
+		}
+	} else if p.blockPrivateIPs && (host == "localhost" || strings.HasSuffix(host, ".localhost")) {
+		return fmt.Errorf("%w: host %q is a loopback name", ErrDestinationRejected, host)
+	}
+
+	if pattern, ok := matchDomain(host, p.deniedDomains); ok {
+		return fmt.Errorf("%w: host %q is denied by %q", ErrDestinationRejected, host, pattern)
+	}
+
+	if len(p.allowedDomains) > 0 {
+		if _, ok := matchDomain(host, p.allowedDomains); !ok {
+			return fmt.Errorf("%w: host %q is not in the allowed domains", ErrDestinationRejected, host)
+		}
+	}
+
+	return nil
+}
+
+func matchDomain(host string, patterns []string) (string, bool) {
+	for _, pattern := range patterns {
+		if suffix, ok := strings.CutPrefix(pattern, "*."); ok {
+			if strings.HasSuffix(host, "."+suffix) {
+				return pattern, true
+			}
+		} else if host == pattern {
+			return pattern, true
+		}
+	}
+
+	return "", false
+}
+
+func isInternalIP(ip netip.Addr) bool {
+	ip = ip.Unmap()
+
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return true
+	}
+
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseIPLiteral recognises IP literals, including the shorthand, octal and
+// hexadecimal IPv4 forms browsers accept such as "127.1" or "0x7f000001".
+func parseIPLiteral(host string) (netip.Addr, bool) {
+	if ip, err := netip.ParseAddr(host); err == nil {
+		return ip, true
+	}
+
+	parts := strings.Split(host, ".")
+	if len(parts) > 4 {
+		return netip.Addr{}, false
+	}
+
+	values := make([]uint64, len(parts))
+	for i, part := range parts {
+		value, err := strconv.ParseUint(part, 0, 32)
+		if err != nil {
+			return netip.Addr{}, false
+		}
+
+		values[i] = value
+	}
+
+	// The last part fills all remaining bytes of the address.
+	last := len(values) - 1
+	if values[last] >= 1<<(8*(4-last)) {
+		return netip.Addr{}, false
+	}
+
+	num := values[last]
+	for i := range last {
+		if values[i] > 0xff {
+			return netip.Addr{}, false
+		}
+
+		num |= values[i] << (8 * (3 - i))
+	}
+
+	return netip.AddrFrom4([4]byte{byte(num >> 24), byte(num >> 16), byte(num >> 8), byte(num)}), true
+}
+
+func lowerAll(values []string) []string {
+	lowered := make([]string, 0, len(values))
+	for _, value := range values {
+		lowered = append(lowered, strings.ToLower(value))
+	}
+
+	return lowered
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ifaisalabid1/url-shortener/internal/model"
+	"github.com/ifaisalabid1/url-shortener/internal/repository"
+	"github.com/skip2/go-qrcode"
+)
+
+var recoveryLevels = map[string]qrcode.RecoveryLevel{
+	"L": qrcode.Low,
+	"M": qrcode.Medium,
+	"Q": qrcode.High,
+	"H": qrcode.Highest,
+}
+
+// GetQRCode renders a QR code for the short URL the caller asked for, so a
+// code requested through an alias encodes the alias. Links that cannot be
+// followed get the same errors as GetOriginalURL.
+func (s *urlService) GetQRCode(ctx context.Context, shortCode string, req *model.QRCodeRequest) ([]byte, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	domain := DomainFromContext(ctx)
+
+	url, err := s.urlRepo.GetByShortCode(ctx, domain, shortCode)
+	if errors.Is(err, repository.ErrURLNotFound) {
+		if _, err := s.urlRepo.GetExpiredByShortCode(ctx, domain, shortCode); err == nil {
+			return nil, ErrURLExpired
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if url.ExpiresAt != nil && url.ExpiresAt.Before(time.Now().UTC()) {
+		return nil, ErrURLExpired
+	}
+
+	if err := checkStatus(url); err != nil {
+		return nil, err
+	}
+
+	key := fmt.Sprintf("%s:%s:%s:%d:%s:%s:%s:%d",
+		url.ID, shortCode, req.Format, req.Size, req.ECC, req.Foreground, req.Background, req.QuietZone)
+
+	cached, err := s.cacheRepo.GetQRCode(ctx, key)
+	if err != nil {
+		fmt.Printf("failed to get QR code from cache: %v\n", err)
+	}
+
+	if cached != nil {
+		return cached, nil
+	}
+
+	code, err := qrcode.New(url.ShortURLFor(s.baseURL, shortCode), recoveryLevels[req.ECC])
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode QR code: %w", err)
+	}
+
+	code.DisableBorder = true
+
+	var data []byte
+	switch req.Format {
+	case "svg":
+		data = renderQRCodeSVG(code.Bitmap(), req)
+	default:
+		data, err = renderQRCodePNG(code.Bitmap(), req)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.cacheRepo.SetQRCode(ctx, key, data, s.cacheTTL); err != nil {
+		fmt.Printf("failed to cache QR code: %v\n", err)
+	}
+
+	return data, nil
+}
+
+// qrCodeScale returns the pixel size of a module and the padding needed to
+// centre the symbol in an image of the requested size.
+func qrCodeScale(modules int, req *model.QRCodeRequest) (scale, offset, size int) {
+	total := modules + 2*req.QuietZone
+
+	scale = max(req.Size/total, 1)
+	size = max(req.Size, scale*total)
+	offset = (size - scale*modules) / 2
+
+	return scale, offset, size
+}
+
+func renderQRCodePNG(bitmap [][]bool, req *model.QRCodeRequest) ([]byte, error) {
+	fg, err := parseHexColor(req.Foreground)
+	if err != nil {
+		return nil, err
+	}
+
+	bg, err := parseHexColor(req.Background)
+	if err != nil {
+		return nil, err
+	}
+
+	scale, offset, size := qrCodeScale(len(bitmap), req)
+
+	img := image.NewPaletted(image.Rect(0, 0, size, size), color.Palette{bg, fg})
+
+	for y, row := range bitmap {
+		for x, set := range row {
+			if !set {
+				continue
+			}
+
+			for dy := range scale {
+				for dx := range scale {
+					img.SetColorIndex(offset+x*scale+dx, offset+y*scale+dy, 1)
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode png: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func renderQRCodeSVG(bitmap [][]bool, req *model.QRCodeRequest) []byte {
+	total := len(bitmap) + 2*req.QuietZone
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`,
+		req.Size, req.Size, total, total)
+	fmt.Fprintf(&buf, `<rect width="100%%" height="100%%" fill="%s"/>`, req.Background)
+	fmt.Fprintf(&buf, `<path fill="%s" d="`, req.Foreground)
+
+	for y, row := range bitmap {
+		for x, set := range row {
+			if set {
+				fmt.Fprintf(&buf, "M%d %dh1v1h-1z", x+req.QuietZone, y+req.QuietZone)
+			}
+		}
+	}
+
+	buf.WriteString(`"/></svg>`)
+
+	return buf.Bytes()
+}
+
+func parseHexColor(hex string) (color.RGBA, error) {
+	hex = strings.TrimPrefix(hex, "#")
+
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil || len(hex) != 6 {
+		return color.RGBA{}, fmt.Errorf("invalid colour %q", hex)
+	}
+
+	return color.RGBA{R: uint8(value >> 16), G: uint8(value >> 8), B: uint8(value), A: 0xff}, nil
+}
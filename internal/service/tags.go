@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/ifaisalabid1/url-shortener/internal/model"
+)
+
+func (s *urlService) ListURLs(ctx context.Context, filter model.URLFilter) ([]*model.URLResponse, error) {
+	filter.Tag = normalizeTag(filter.Tag)
+	filter.Folder = strings.TrimSpace(filter.Folder)
+
+	urls, err := s.urlRepo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*model.URLResponse, 0, len(urls))
+	for _, url := range urls {
+		res = append(res, url.ToResponse(s.baseURL))
+	}
+
+	return res, nil
+}
+
+func (s *urlService) ListTagStats(ctx context.Context) ([]*model.TagStats, error) {
+	return s.urlRepo.TagStats(ctx)
+}
+
+// normalizeTags lower-cases and trims tags, dropping blanks and duplicates so
+// that "Sale" and "sale " are the same tag.
+func normalizeTags(tags []string) []string {
+	res := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if tag = normalizeTag(tag); tag != "" {
+			res = append(res, tag)
+		}
+	}
+
+	slices.Sort(res)
+
+	return slices.Compact(res)
+}
+
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
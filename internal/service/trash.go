@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ifaisalabid1/url-shortener/internal/model"
+	"github.com/ifaisalabid1/url-shortener/internal/repository"
+)
+
+// DeleteURL moves a link to the trash. Its code stays reserved and its stats
+// are kept until the trash is purged.
+func (s *urlService) DeleteURL(ctx context.Context, shortCode string) error {
+	url, err := s.urlRepo.GetByShortCode(ctx, DomainFromContext(ctx), shortCode)
+	if err != nil {
+		return err
+	}
+
+	history := newHistoryEntry(ctx, url.ID, model.HistoryDelete, url.Snapshot(), nil)
+
+	if err := s.urlRepo.SoftDelete(ctx, url.ID, history); err != nil {
+		return err
+	}
+
+	evictURL(ctx, s.cacheRepo, s.aliasRepo, url)
+
+	return nil
+}
+
+func (s *urlService) ListTrash(ctx context.Context, limit, offset int) ([]*model.URLResponse, error) {
+	urls, err := s.urlRepo.ListDeleted(ctx, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*model.URLResponse, 0, len(urls))
+	for _, url := range urls {
+		res = append(res, url.ToResponse(s.baseURL))
+	}
+
+	return res, nil
+}
+
+func (s *urlService) RestoreURL(ctx context.Context, shortCode string) (*model.URLResponse, error) {
+	url, err := s.urlRepo.GetDeletedByShortCode(ctx, DomainFromContext(ctx), shortCode)
+	if err != nil {
+		return nil, err
+	}
+
+	url.DeletedAt = nil
+	history := newHistoryEntry(ctx, url.ID, model.HistoryRestore, nil, url.Snapshot())
+
+	if err := s.urlRepo.Restore(ctx, url.ID, history); err != nil {
+		return nil, err
+	}
+
+	return url.ToResponse(s.baseURL), nil
+}
+
+// PurgeURL permanently deletes a link from the trash.
+func (s *urlService) PurgeURL(ctx context.Context, shortCode string) error {
+	url, err := s.urlRepo.GetDeletedByShortCode(ctx, DomainFromContext(ctx), shortCode)
+	if err != nil {
+		return err
+	}
+
+	return s.purge(ctx, url)
+}
+
+// PurgeTrash permanently deletes up to limit links moved to the trash before
+// deletedBefore, the same way PurgeURL does, and returns how many were
+// purged.
+func (s *urlService) PurgeTrash(ctx context.Context, deletedBefore time.Time, limit int) (int, error) {
+	ctx = withSystemActor(ctx)
+
+	urls, err := s.urlRepo.ListDeletedBefore(ctx, deletedBefore, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, url := range urls {
+		// Another instance or an admin may have purged or restored the
+		// link first.
+		if err := s.purge(ctx, url); err != nil {
+			if errors.Is(err, repository.ErrURLNotFound) {
+				continue
+			}
+
+			return purged, err
+		}
+
+		purged++
+	}
+
+	return purged, nil
+}
+
+func (s *urlService) purge(ctx context.Context, url *model.URL) error {
+	history := newHistoryEntry(ctx, url.ID, model.HistoryPurge, url.Snapshot(), nil)
+
+	return s.urlRepo.Purge(ctx, url.ID, history)
+}
+
+// DeleteExpiredURLs permanently deletes up to limit expired links, recording
+// each deletion in their history, and returns how many were deleted.
+func (s *urlService) DeleteExpiredURLs(ctx context.Context, limit int) (int, error) {
+	ctx = withSystemActor(ctx)
+
+	urls, err := s.urlRepo.ListExpired(ctx, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, url := range urls {
+		evictURL(ctx, s.cacheRepo, s.aliasRepo, url)
+
+		history := newHistoryEntry(ctx, url.ID, model.HistoryExpire, url.Snapshot(), nil)
+
+		// Another instance may have deleted the link first.
+		if err := s.urlRepo.DeleteExpired(ctx, url.ID, history); err != nil {
+			if errors.Is(err, repository.ErrURLNotFound) {
+				continue
+			}
+
+			return deleted, err
+		}
+
+		deleted++
+	}
+
+	return deleted, nil
+}
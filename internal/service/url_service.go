@@ -3,8 +3,14 @@ package service
 import (
 	"context"
 	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"maps"
 	"math/big"
+	neturl "net/url"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,25 +21,92 @@ import (
 
 type URLService interface {
 	CreateShortURL(ctx context.Context, req *model.CreateURLRequest) (*model.URLResponse, error)
-	GetOriginalURL(ctx context.Context, shortCode string) (string, error)
+	GetOriginalURL(ctx context.Context, shortCode, acceptLanguage string) (*model.Redirect, error)
 	GetURLStats(ctx context.Context, shortCode string) (*model.URLStats, error)
+	LiveClicks(ctx context.Context, shortCode string, lastEventID int64) (<-chan *model.ClickEvent, error)
+	GetURLPreview(ctx context.Context, shortCode string) (*model.URLResponse, error)
+	UpdateURL(ctx context.Context, shortCode string, req *model.UpdateURLRequest) (*model.URLResponse, error)
+	GetQRCode(ctx context.Context, shortCode string, req *model.QRCodeRequest) ([]byte, error)
+	CreateAlias(ctx context.Context, shortCode string, req *model.CreateAliasRequest) (*model.AliasResponse, error)
+	ListAliases(ctx context.Context, shortCode string) ([]*model.AliasResponse, error)
+	ListAliasesByURLIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]*model.AliasResponse, error)
+	DeleteAlias(ctx context.Context, shortCode, alias string) error
+	ListBrokenURLs(ctx context.Context, limit, offset int) ([]*model.URLResponse, error)
+	DeleteURL(ctx context.Context, shortCode string) error
+	ListTrash(ctx context.Context, limit, offset int) ([]*model.URLResponse, error)
+	RestoreURL(ctx context.Context, shortCode string) (*model.URLResponse, error)
+	PurgeURL(ctx context.Context, shortCode string) error
+	GetURLHistory(ctx context.Context, id uuid.UUID) ([]*model.HistoryEntry, error)
+	ListHistoryByURLIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]*model.HistoryEntry, error)
+	RevertURL(ctx context.Context, id uuid.UUID, version int) (*model.URLResponse, error)
+	ListURLs(ctx context.Context, filter model.URLFilter) ([]*model.URLResponse, error)
+	ListTagStats(ctx context.Context) ([]*model.TagStats, error)
+	RehashStaleURLs(ctx context.Context, limit int) (int, error)
+	DeleteExpiredURLs(ctx context.Context, limit int) (int, error)
+	PurgeTrash(ctx context.Context, deletedBefore time.Time, limit int) (int, error)
 }
 
+var (
+	ErrURLDisabled = errors.New("url disabled")
+	ErrURLBanned   = errors.New("url banned")
+	ErrURLExpired  = errors.New("url expired")
+
+	ErrInterstitialOptOut = errors.New("only trusted callers can turn off the interstitial")
+)
+
 type urlService struct {
-	urlRepo   repository.URLRepository
-	cacheRepo repository.CacheRepository
-	baseURL   string
-	shortLen  int
-	cacheTTL  time.Duration
+	urlRepo        repository.URLRepository
+	aliasRepo      repository.AliasRepository
+	moderationRepo repository.ModerationRepository
+	historyRepo    repository.HistoryRepository
+	cacheRepo      repository.CacheRepository
+	baseURL        string
+	shortLen       int
+	cacheTTL       time.Duration
+	reuseExisting  bool
+	normalizer     *Normalizer
+	policy         *DestinationPolicy
+	blocklist      *Blocklist
+	domains        DomainService
+	webhooks       WebhookService
+
+	// interstitialTrust holds the creator trust levels whose links show an
+	// interstitial unless the link overrides it.
+	interstitialTrust map[string]bool
+
+	// clickThresholds are the click counts that publish
+	// link.click_threshold when a link reaches them.
+	clickThresholds map[int64]bool
 }
 
-func NewURLService(urlRepo repository.URLRepository, cacheRepo repository.CacheRepository, baseURL string, shortLen int, cacheTTL time.Duration) URLService {
+func NewURLService(urlRepo repository.URLRepository, aliasRepo repository.AliasRepository, moderationRepo repository.ModerationRepository, historyRepo repository.HistoryRepository, cacheRepo repository.CacheRepository, baseURL string, shortLen int, cacheTTL time.Duration, reuseExisting bool, normalizer *Normalizer, policy *DestinationPolicy, blocklist *Blocklist, domains DomainService, webhooks WebhookService, interstitialTrust []string, clickThresholds []int64) URLService {
+	levels := make(map[string]bool, len(interstitialTrust))
+	for _, level := range interstitialTrust {
+		levels[level] = true
+	}
+
+	thresholds := make(map[int64]bool, len(clickThresholds))
+	for _, threshold := range clickThresholds {
+		thresholds[threshold] = true
+	}
+
 	return &urlService{
 		urlRepo,
+		aliasRepo,
+		moderationRepo,
+		historyRepo,
 		cacheRepo,
 		baseURL,
 		shortLen,
 		cacheTTL,
+		reuseExisting,
+		normalizer,
+		policy,
+		blocklist,
+		domains,
+		webhooks,
+		levels,
+		thresholds,
 	}
 }
 
@@ -42,44 +115,109 @@ func (s *urlService) CreateShortURL(ctx context.Context, req *model.CreateURLReq
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
 
-	var shortCode string
-	if req.CustomCode != nil && *req.CustomCode != "" {
-		existing, err := s.urlRepo.GetByShortCode(ctx, *req.CustomCode)
-		if err == nil && existing != nil {
-			return nil, repository.ErrDuplicateCode
-		}
+	// The interstitial protects visitors from untrusted creators, so only
+	// trusted callers may opt their links out of it.
+	if req.Interstitial != nil && !*req.Interstitial && ActorFromContext(ctx).Trust != model.TrustTrusted {
+		return nil, ErrInterstitialOptOut
+	}
 
-		shortCode = *req.CustomCode
-	} else {
-		shortCode = s.generateShortCode(req.OriginalURL)
+	normalizedURL, err := s.prepareDestination(ctx, req.OriginalURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkLanguageTargets(ctx, req.LanguageTargets); err != nil {
+		return nil, err
+	}
+
+	domain := DomainFromContext(ctx)
+	if req.Domain != "" {
+		domain = NormalizeHost(req.Domain)
+	}
+
+	if domain != "" && !s.domains.Has(domain) {
+		return nil, ErrUnknownDomain
 	}
 
 	now := time.Now().UTC()
+	actor := ActorFromContext(ctx)
 
 	url := &model.URL{
 		ID:          uuid.New(),
-		ShortCode:   shortCode,
+		Domain:      domain,
 		OriginalURL: req.OriginalURL,
 		CreatedAt:   now,
 		UpdatedAt:   now,
 		Clicks:      0,
 		ExpiresAt:   req.ExpiresAt,
+
+		LanguageTargets: req.LanguageTargets,
+		OpenGraph:       req.OpenGraph,
+		NormalizedURL:   normalizedURL,
+		URLHash:         hashURL(normalizedURL),
+		Status:          model.URLStatusActive,
+		Interstitial:    req.Interstitial,
+		CreatorTrust:    actor.Trust,
+		Creator:         actor.Scope(),
+		Folder:          strings.TrimSpace(req.Folder),
+		Tags:            normalizeTags(req.Tags),
+	}
+
+	reuse := s.reuseExisting
+	if req.ReuseExisting != nil {
+		reuse = *req.ReuseExisting
+	}
+
+	// A custom code is an explicit request for a new link, so it is never
+	// satisfied by an existing one. Only the caller's own links with the
+	// same settings are reused, so nobody is handed a link they cannot
+	// manage or one that behaves differently from what they asked for.
+	if reuse && (req.CustomCode == nil || *req.CustomCode == "") {
+		existing, err := s.urlRepo.GetByURLHash(ctx, domain, url.Creator, url.URLHash)
+		if err == nil && sameSettings(existing, url) {
+			res := existing.ToResponse(s.baseURL)
+			res.Reused = true
+
+			return res, nil
+		}
+
+		if err != nil && !errors.Is(err, repository.ErrURLNotFound) {
+			return nil, fmt.Errorf("failed to look up existing url: %w", err)
+		}
+	}
+
+	if req.CustomCode != nil && *req.CustomCode != "" {
+		existing, err := s.urlRepo.GetByShortCode(ctx, domain, *req.CustomCode)
+		if err == nil && existing != nil {
+			return nil, repository.ErrDuplicateCode
+		}
+
+		url.ShortCode = *req.CustomCode
+	} else {
+		url.ShortCode = s.generateShortCode(req.OriginalURL)
 	}
 
-	if err := s.urlRepo.Create(ctx, url); err != nil {
+	history := newHistoryEntry(ctx, url.ID, model.HistoryCreate, nil, url.Snapshot())
+
+	if err := s.urlRepo.Create(ctx, url, history); err != nil {
 		return nil, fmt.Errorf("failed to create url: %w", err)
 	}
 
-	if err := s.cacheRepo.SetURL(ctx, shortCode, url, s.cacheTTL); err != nil {
+	if err := s.cacheRepo.SetURL(ctx, domain, url.ShortCode, url, s.cacheTTL); err != nil {
 		fmt.Printf("failed to cache url: %v\n", err)
 	}
 
-	return url.ToResponse(s.baseURL), nil
+	res := url.ToResponse(s.baseURL)
+	s.publish(ctx, url.Creator, model.EventLinkCreated, res)
+
+	return res, nil
 
 }
 
-func (s *urlService) GetOriginalURL(ctx context.Context, shortCode string) (string, error) {
-	cachedURL, err := s.cacheRepo.GetURL(ctx, shortCode)
+func (s *urlService) GetOriginalURL(ctx context.Context, shortCode, acceptLanguage string) (*model.Redirect, error) {
+	domain := DomainFromContext(ctx)
+
+	cachedURL, err := s.cacheRepo.GetURL(ctx, domain, shortCode)
 	if err != nil {
 		fmt.Printf("failed to get from cache: %v\n", err)
 	}
@@ -89,41 +227,344 @@ func (s *urlService) GetOriginalURL(ctx context.Context, shortCode string) (stri
 	if cachedURL != nil {
 		url = cachedURL
 	} else {
-		url, err := s.urlRepo.GetByShortCode(ctx, shortCode)
+		url, err = s.urlRepo.GetByShortCode(ctx, domain, shortCode)
+		if errors.Is(err, repository.ErrURLNotFound) {
+			if _, err := s.urlRepo.GetExpiredByShortCode(ctx, domain, shortCode); err == nil {
+				return nil, ErrURLExpired
+			}
+		}
+
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 
-		if err := s.cacheRepo.SetURL(ctx, shortCode, url, s.cacheTTL); err != nil {
+		if err := s.cacheRepo.SetURL(ctx, domain, shortCode, url, s.cacheTTL); err != nil {
 			fmt.Printf("Failed to cache URL: %v\n", err)
 		}
 	}
 
 	if url.ExpiresAt != nil && url.ExpiresAt.Before(time.Now().UTC()) {
-		return "", repository.ErrURLNotFound
+		return nil, ErrURLExpired
+	}
+
+	if err := checkStatus(url); err != nil {
+		return nil, err
+	}
+
+	redirect := &model.Redirect{
+		ShortCode:    shortCode,
+		Destination:  selectDestination(url, acceptLanguage),
+		Interstitial: s.interstitialTrust[url.CreatorTrust],
+		Negotiated:   len(url.LanguageTargets) > 0,
+	}
+
+	// Links can always opt in, but an opt-out only counts on links made by
+	// trusted creators, including ones stored before opting out was checked.
+	if url.Interstitial != nil && (*url.Interstitial || url.CreatorTrust == model.TrustTrusted) {
+		redirect.Interstitial = *url.Interstitial
+	}
+
+	redirect.FlagReason, redirect.Flagged = s.checkBlocklist(redirect.Destination)
+
+	if redirect.Flagged {
+		return redirect, nil
 	}
 
 	go func() {
-		if err := s.urlRepo.IncrementClicks(ctx, shortCode); err != nil {
+		ctx := context.WithoutCancel(ctx)
+
+		clicks, err := s.urlRepo.IncrementClicks(ctx, domain, shortCode)
+		if err != nil {
 			fmt.Printf("failed to increment clicks: %v\n", err)
+			return
+		}
+
+		event := &model.ClickEvent{ShortCode: shortCode, Clicks: clicks, ClickedAt: time.Now().UTC()}
+		if err := s.cacheRepo.PublishClick(ctx, url.ID, event); err != nil {
+			fmt.Printf("failed to publish click: %v\n", err)
+		}
+
+		if s.clickThresholds[clicks] {
+			link := url.ToResponse(s.baseURL)
+			link.Clicks = clicks
+
+			s.publish(ctx, url.Creator, model.EventLinkClickThreshold, &model.ClickThresholdData{Link: link, Threshold: clicks})
 		}
 	}()
 
-	return url.OriginalURL, nil
+	return redirect, nil
+}
+
+func (s *urlService) UpdateURL(ctx context.Context, shortCode string, req *model.UpdateURLRequest) (*model.URLResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	url, err := s.urlRepo.GetByShortCode(ctx, DomainFromContext(ctx), shortCode)
+	if err != nil {
+		return nil, err
+	}
+
+	before := url.Snapshot()
+
+	if req.OriginalURL != nil {
+		normalizedURL, err := s.prepareDestination(ctx, *req.OriginalURL)
+		if err != nil {
+			return nil, err
+		}
+
+		url.OriginalURL = *req.OriginalURL
+		url.NormalizedURL = normalizedURL
+		url.URLHash = hashURL(normalizedURL)
+	}
+
+	if req.ExpiresAt != nil {
+		url.ExpiresAt = req.ExpiresAt
+	}
+
+	if req.LanguageTargets != nil {
+		if err := s.checkLanguageTargets(ctx, req.LanguageTargets); err != nil {
+			return nil, err
+		}
+
+		url.LanguageTargets = req.LanguageTargets
+	}
+
+	if req.OpenGraph != nil {
+		url.OpenGraph = req.OpenGraph
+	}
+
+	if req.Interstitial != nil {
+		url.Interstitial = req.Interstitial
+	}
+
+	if req.Folder != nil {
+		url.Folder = strings.TrimSpace(*req.Folder)
+	}
+
+	if req.Tags != nil {
+		url.Tags = normalizeTags(*req.Tags)
+	}
+
+	url.UpdatedAt = time.Now().UTC()
+
+	history := newHistoryEntry(ctx, url.ID, model.HistoryUpdate, before, url.Snapshot())
+
+	if err := s.urlRepo.Update(ctx, url, history); err != nil {
+		return nil, fmt.Errorf("failed to update url: %w", err)
+	}
+
+	evictURL(ctx, s.cacheRepo, s.aliasRepo, url)
+
+	res := url.ToResponse(s.baseURL)
+	s.publish(ctx, url.Creator, model.EventLinkUpdated, res)
+
+	return res, nil
 }
 
 func (s *urlService) GetURLStats(ctx context.Context, shortCode string) (*model.URLStats, error) {
-	url, err := s.urlRepo.GetByShortCode(ctx, shortCode)
+	url, err := s.urlRepo.GetByShortCode(ctx, DomainFromContext(ctx), shortCode)
+	if err != nil {
+		return nil, err
+	}
+
+	aliases, err := s.aliasRepo.ListByURLID(ctx, url.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	return &model.URLStats{
+	stats := &model.URLStats{
 		ShortCode:   url.ShortCode,
 		OriginalURL: url.OriginalURL,
 		Clicks:      url.Clicks,
 		CreatedAt:   url.CreatedAt,
-	}, nil
+
+		Folder: url.Folder,
+		Tags:   url.Tags,
+	}
+
+	for _, alias := range aliases {
+		stats.Aliases = append(stats.Aliases, &model.AliasStats{
+			ShortCode: alias.ShortCode,
+			Clicks:    alias.Clicks,
+		})
+	}
+
+	return stats, nil
+}
+
+func (s *urlService) GetURLPreview(ctx context.Context, shortCode string) (*model.URLResponse, error) {
+	url, err := s.urlRepo.GetByShortCode(ctx, DomainFromContext(ctx), shortCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkStatus(url); err != nil {
+		return nil, err
+	}
+
+	res := url.ToResponse(s.baseURL)
+	res.FlagReason, res.Flagged = s.checkBlocklist(url.OriginalURL)
+
+	return res, nil
+}
+
+func (s *urlService) ListBrokenURLs(ctx context.Context, limit, offset int) ([]*model.URLResponse, error) {
+	urls, err := s.urlRepo.ListBroken(ctx, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*model.URLResponse, 0, len(urls))
+	for _, url := range urls {
+		res = append(res, url.ToResponse(s.baseURL))
+	}
+
+	return res, nil
+}
+
+// prepareDestination normalizes a destination and checks it against the
+// destination policy, blocklist and banned domains, returning the normalized
+// form.
+func (s *urlService) prepareDestination(ctx context.Context, rawURL string) (string, error) {
+	normalizedURL, err := s.normalizer.Normalize(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.policy.Check(normalizedURL); err != nil {
+		return "", err
+	}
+
+	if reason, blocked := s.blocklist.Check(normalizedURL); blocked {
+		return "", fmt.Errorf("%w: %s", ErrDestinationRejected, reason)
+	}
+
+	if u, err := neturl.Parse(normalizedURL); err == nil && u.Hostname() != "" {
+		banned, err := s.moderationRepo.IsDomainBanned(ctx, hostSuffixes(u.Hostname()))
+		if err != nil {
+			return "", err
+		}
+
+		if banned {
+			return "", fmt.Errorf("%w: host %q is banned", ErrDestinationRejected, u.Hostname())
+		}
+	}
+
+	return normalizedURL, nil
+}
+
+// checkBlocklist reports whether a stored destination is on a blocklist.
+// Blocklists are reloaded independently of link creation, so destinations
+// are checked again whenever a link is served.
+func (s *urlService) checkBlocklist(destination string) (string, bool) {
+	normalized, err := s.normalizer.Normalize(destination)
+	if err != nil {
+		return "", false
+	}
+
+	return s.blocklist.Check(normalized)
+}
+
+func (s *urlService) checkLanguageTargets(ctx context.Context, targets model.LanguageTargets) error {
+	for tag, target := range targets {
+		if _, err := s.prepareDestination(ctx, target); err != nil {
+			return fmt.Errorf("language target %q: %w", tag, err)
+		}
+	}
+
+	return nil
+}
+
+// evictURL drops a link and all of its aliases from the cache.
+func evictURL(ctx context.Context, cacheRepo repository.CacheRepository, aliasRepo repository.AliasRepository, url *model.URL) {
+	links := []model.ShortLink{{Domain: url.Domain, ShortCode: url.ShortCode}}
+
+	aliases, err := aliasRepo.ListByURLID(ctx, url.ID)
+	if err != nil {
+		fmt.Printf("failed to list aliases for eviction: %v\n", err)
+	}
+
+	for _, alias := range aliases {
+		links = append(links, model.ShortLink{Domain: alias.Domain, ShortCode: alias.ShortCode})
+	}
+
+	evictLinks(ctx, cacheRepo, links)
+}
+
+func evictLinks(ctx context.Context, cacheRepo repository.CacheRepository, links []model.ShortLink) {
+	for _, link := range links {
+		if err := cacheRepo.DeleteURL(ctx, link.Domain, link.ShortCode); err != nil {
+			fmt.Printf("failed to evict url from cache: %v\n", err)
+		}
+	}
+}
+
+// checkStatus reports links taken down by moderators.
+func checkStatus(url *model.URL) error {
+	switch url.Status {
+	case model.URLStatusDisabled:
+		return ErrURLDisabled
+	case model.URLStatusBanned:
+		return ErrURLBanned
+	default:
+		return nil
+	}
+}
+
+// publish queues a webhook event. The change it reports has already been
+// applied, so failures are logged rather than returned.
+func (s *urlService) publish(ctx context.Context, owner, eventType string, data any) {
+	if err := s.webhooks.Publish(ctx, owner, eventType, data); err != nil {
+		fmt.Printf("failed to publish %s webhook: %v\n", eventType, err)
+	}
+}
+
+func hashURL(url string) string {
+	hash := sha256.Sum256([]byte(url))
+	return hex.EncodeToString(hash[:])
+}
+
+// sameSettings reports whether an existing link behaves like the candidate
+// for a new one to the same destination.
+func sameSettings(existing, candidate *model.URL) bool {
+	return equalPtr(existing.ExpiresAt, candidate.ExpiresAt, time.Time.Equal) &&
+		maps.Equal(existing.LanguageTargets, candidate.LanguageTargets) &&
+		equalPtr(existing.OpenGraph, candidate.OpenGraph, func(a, b model.OpenGraph) bool { return a == b }) &&
+		equalPtr(existing.Interstitial, candidate.Interstitial, func(a, b bool) bool { return a == b }) &&
+		existing.Folder == candidate.Folder &&
+		slices.Equal(existing.Tags, candidate.Tags)
+}
+
+func equalPtr[T any](a, b *T, eq func(T, T) bool) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return eq(*a, *b)
+}
+
+// RehashStaleURLs recomputes the normalized URL and hash of up to limit
+// links created before URL normalization, returning how many were updated.
+func (s *urlService) RehashStaleURLs(ctx context.Context, limit int) (int, error) {
+	urls, err := s.urlRepo.ListStaleHashes(ctx, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, url := range urls {
+		// Destinations the normalizer now rejects keep their stored form.
+		normalizedURL, err := s.normalizer.Normalize(url.OriginalURL)
+		if err != nil {
+			normalizedURL = url.NormalizedURL
+		}
+
+		if err := s.urlRepo.UpdateHash(ctx, url.ID, normalizedURL, hashURL(normalizedURL)); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(urls), nil
 }
 
 func (s *urlService) generateShortCode(originalURL string) string {
@@ -0,0 +1,366 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ifaisalabid1/url-shortener/internal/model"
+	"github.com/ifaisalabid1/url-shortener/internal/repository"
+)
+
+const webhookUserAgent = "url-shortener-webhooks/1.0"
+
+// Headers sent with every webhook delivery.
+const (
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+	WebhookSignatureHeader = "X-Webhook-Signature"
+)
+
+// WebhookService manages webhook subscriptions and queues link events for
+// them. Events are delivered by a WebhookDispatcher.
+//
+// Subscriptions belong to the caller that created them, identified as for
+// link reuse, and only receive events for links that caller created.
+type WebhookService interface {
+	Create(ctx context.Context, req *model.CreateWebhookRequest) (*model.WebhookSubscription, error)
+	List(ctx context.Context) ([]*model.WebhookSubscription, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	Publish(ctx context.Context, owner, eventType string, data any) error
+	NotifyExpired(ctx context.Context, limit int) (int, error)
+	ListDeliveries(ctx context.Context, status string, limit, offset int) ([]*model.WebhookDelivery, error)
+	ListAttempts(ctx context.Context, deliveryID uuid.UUID) ([]*model.WebhookAttempt, error)
+	Redeliver(ctx context.Context, deliveryID uuid.UUID) error
+}
+
+type webhookService struct {
+	webhookRepo repository.WebhookRepository
+	urlRepo     repository.URLRepository
+	baseURL     string
+}
+
+func NewWebhookService(webhookRepo repository.WebhookRepository, urlRepo repository.URLRepository, baseURL string) WebhookService {
+	return &webhookService{webhookRepo, urlRepo, baseURL}
+}
+
+func (s *webhookService) Create(ctx context.Context, req *model.CreateWebhookRequest) (*model.WebhookSubscription, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		secret = rand.Text()
+	}
+
+	sub := &model.WebhookSubscription{
+		ID:        uuid.New(),
+		URL:       req.URL,
+		Secret:    secret,
+		Events:    req.Events,
+		Active:    true,
+		Owner:     ActorFromContext(ctx).Scope(),
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if err := s.webhookRepo.CreateSubscription(ctx, sub); err != nil {
+		return nil, err
+	}
+
+	return sub, nil
+}
+
+// List returns the caller's subscriptions without their secrets, which are
+// only shown when a subscription is created.
+func (s *webhookService) List(ctx context.Context) ([]*model.WebhookSubscription, error) {
+	subs, err := s.webhookRepo.ListSubscriptions(ctx, ActorFromContext(ctx).Scope())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sub := range subs {
+		sub.Secret = ""
+	}
+
+	return subs, nil
+}
+
+func (s *webhookService) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.webhookRepo.DeleteSubscription(ctx, id, ActorFromContext(ctx).Scope())
+}
+
+// Publish queues an event for every subscription to its type that belongs
+// to owner, the creator of the link the event is about.
+func (s *webhookService) Publish(ctx context.Context, owner, eventType string, data any) error {
+	event := model.WebhookEvent{
+		ID:        uuid.New(),
+		Type:      eventType,
+		CreatedAt: time.Now().UTC(),
+		Data:      data,
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook event: %w", err)
+	}
+
+	if _, err := s.webhookRepo.Enqueue(ctx, owner, event.ID, eventType, payload); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// NotifyExpired publishes link.expired for up to limit links that have
+// expired since the last call, returning how many were published.
+func (s *webhookService) NotifyExpired(ctx context.Context, limit int) (int, error) {
+	urls, err := s.urlRepo.ClaimNewlyExpired(ctx, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, url := range urls {
+		if err := s.Publish(ctx, url.Creator, model.EventLinkExpired, url.ToResponse(s.baseURL)); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(urls), nil
+}
+
+func (s *webhookService) ListDeliveries(ctx context.Context, status string, limit, offset int) ([]*model.WebhookDelivery, error) {
+	return s.webhookRepo.ListDeliveries(ctx, status, limit, offset)
+}
+
+func (s *webhookService) ListAttempts(ctx context.Context, deliveryID uuid.UUID) ([]*model.WebhookAttempt, error) {
+	return s.webhookRepo.ListAttempts(ctx, deliveryID)
+}
+
+// Redeliver moves a dead delivery back into the queue.
+func (s *webhookService) Redeliver(ctx context.Context, deliveryID uuid.UUID) error {
+	return s.webhookRepo.Requeue(ctx, deliveryID)
+}
+
+// SignWebhook returns the signature header value for body sent at t:
+// "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<t>.<body>">". Receivers should
+// recompute it and reject stale timestamps.
+func SignWebhook(secret string, t time.Time, body []byte) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "t=" + ts + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookDispatcher sends queued webhook deliveries, retrying failures with
+// exponential backoff until they run out of attempts.
+type WebhookDispatcher struct {
+	webhookRepo repository.WebhookRepository
+	client      *http.Client
+	concurrency int
+	batchSize   int
+	maxAttempts int
+	backoff     time.Duration
+	maxBackoff  time.Duration
+
+	mu   sync.Mutex
+	subs map[uuid.UUID]*model.WebhookSubscription
+}
+
+// NewWebhookDispatcher returns a WebhookDispatcher that sends at most
+// concurrency deliveries at once and waits backoff, doubling up to
+// maxBackoff, between attempts at the same delivery. Deliveries go through
+// client, normally one from NewWebhookClient.
+func NewWebhookDispatcher(webhookRepo repository.WebhookRepository, client *http.Client, concurrency, batchSize, maxAttempts int, backoff, maxBackoff time.Duration) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		webhookRepo: webhookRepo,
+		client:      client,
+		concurrency: max(concurrency, 1),
+		batchSize:   batchSize,
+		maxAttempts: max(maxAttempts, 1),
+		backoff:     backoff,
+		maxBackoff:  maxBackoff,
+	}
+}
+
+// NewWebhookClient returns an HTTP client for webhook deliveries. It applies
+// the address checks of policy, as the health checker does, and does not
+// follow redirects, so a 3xx counts as a failed delivery. Receivers on
+// private addresses are only reachable when policy allows them.
+func NewWebhookClient(timeout time.Duration, policy *DestinationPolicy) *http.Client {
+	client := NewHealthCheckClient(timeout, policy)
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	return client
+}
+
+// DeliverDue sends a batch of deliveries whose next attempt is due,
+// returning how many succeeded and how many failed.
+func (d *WebhookDispatcher) DeliverDue(ctx context.Context) (delivered, failed int, err error) {
+	// Claimed deliveries are hidden from other instances until the lease
+	// runs out, which must outlast sending the whole batch.
+	lease := d.client.Timeout*time.Duration(d.batchSize/d.concurrency+1) + time.Minute
+
+	deliveries, err := d.webhookRepo.ClaimDue(ctx, lease, d.batchSize)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	d.mu.Lock()
+	d.subs = map[uuid.UUID]*model.WebhookSubscription{}
+	d.mu.Unlock()
+
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, d.concurrency)
+	)
+
+	for _, delivery := range deliveries {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			ok, err := d.deliver(ctx, delivery)
+			if err != nil {
+				fmt.Printf("failed to deliver webhook: %v\n", err)
+				return
+			}
+
+			mu.Lock()
+			if ok {
+				delivered++
+			} else {
+				failed++
+			}
+			mu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+
+	return delivered, failed, ctx.Err()
+}
+
+// deliver makes one attempt at a delivery and records the outcome.
+func (d *WebhookDispatcher) deliver(ctx context.Context, delivery *model.WebhookDelivery) (bool, error) {
+	sub, err := d.subscription(ctx, delivery.SubscriptionID)
+	if err != nil {
+		return false, err
+	}
+
+	start := time.Now()
+	statusCode, sendErr := d.send(ctx, sub, delivery)
+
+	now := time.Now().UTC()
+	delivery.Attempts++
+
+	attempt := &model.WebhookAttempt{
+		ID:         uuid.New(),
+		DeliveryID: delivery.ID,
+		Attempt:    delivery.Attempts,
+		StatusCode: statusCode,
+		DurationMS: time.Since(start).Milliseconds(),
+		CreatedAt:  now,
+	}
+
+	if sendErr != nil {
+		attempt.Error = sendErr.Error()
+	}
+
+	delivery.LastStatusCode = attempt.StatusCode
+	delivery.LastError = attempt.Error
+
+	switch {
+	case sendErr == nil:
+		delivery.Status = model.DeliveryDelivered
+		delivery.DeliveredAt = &now
+	case delivery.Attempts >= d.maxAttempts:
+		delivery.Status = model.DeliveryDead
+	default:
+		delivery.NextAttemptAt = now.Add(d.retryDelay(delivery.Attempts))
+	}
+
+	if err := d.webhookRepo.RecordAttempt(ctx, delivery, attempt); err != nil {
+		return false, err
+	}
+
+	return sendErr == nil, nil
+}
+
+func (d *WebhookDispatcher) send(ctx context.Context, sub *model.WebhookSubscription, delivery *model.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", webhookUserAgent)
+	req.Header.Set(WebhookEventHeader, delivery.Event)
+	req.Header.Set(WebhookDeliveryHeader, delivery.ID.String())
+	req.Header.Set(WebhookSignatureHeader, SignWebhook(sub.Secret, time.Now(), delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("receiver responded with %s", resp.Status)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// retryDelay returns the wait before the attempt after the given one.
+func (d *WebhookDispatcher) retryDelay(attempts int) time.Duration {
+	delay := d.backoff
+	for i := 1; i < attempts && delay < d.maxBackoff; i++ {
+		delay *= 2
+	}
+
+	return min(delay, d.maxBackoff)
+}
+
+// subscription loads a subscription once per batch.
+func (d *WebhookDispatcher) subscription(ctx context.Context, id uuid.UUID) (*model.WebhookSubscription, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if sub, ok := d.subs[id]; ok {
+		return sub, nil
+	}
+
+	sub, err := d.webhookRepo.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	d.subs[id] = sub
+
+	return sub, nil
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ifaisalabid1/url-shortener/internal/model"
+	"github.com/ifaisalabid1/url-shortener/internal/repository"
+)
+
+// fakeWebhookRepo serves one subscription and records delivery outcomes.
+// Methods the dispatcher does not call are left to the nil interface.
+type fakeWebhookRepo struct {
+	repository.WebhookRepository
+
+	sub        *model.WebhookSubscription
+	deliveries []*model.WebhookDelivery
+	attempts   []*model.WebhookAttempt
+}
+
+func (r *fakeWebhookRepo) ClaimDue(ctx context.Context, lease time.Duration, limit int) ([]*model.WebhookDelivery, error) {
+	return r.deliveries, nil
+}
+
+func (r *fakeWebhookRepo) GetSubscription(ctx context.Context, id uuid.UUID) (*model.WebhookSubscription, error) {
+	return r.sub, nil
+}
+
+func (r *fakeWebhookRepo) RecordAttempt(ctx context.Context, delivery *model.WebhookDelivery, attempt *model.WebhookAttempt) error {
+	r.attempts = append(r.attempts, attempt)
+	return nil
+}
+
+// testPolicy lets clients reach httptest servers on the loopback address,
+// which the production policy refuses.
+func testPolicy(deniedDomains ...string) *DestinationPolicy {
+	return NewDestinationPolicy([]string{"http", "https"}, nil, deniedDomains, false)
+}
+
+// verifySignature checks a signature header the way a receiver would.
+func verifySignature(secret, header string, body []byte) bool {
+	ts, mac, ok := strings.Cut(header, ",")
+	if !ok || !strings.HasPrefix(ts, "t=") || !strings.HasPrefix(mac, "v1=") {
+		return false
+	}
+
+	want := hmac.New(sha256.New, []byte(secret))
+	want.Write([]byte(strings.TrimPrefix(ts, "t=") + "."))
+	want.Write(body)
+
+	got, err := hex.DecodeString(strings.TrimPrefix(mac, "v1="))
+	if err != nil {
+		return false
+	}
+
+	return hmac.Equal(got, want.Sum(nil))
+}
+
+func TestSignWebhook(t *testing.T) {
+	body := []byte(`{"type":"link.created"}`)
+	now := time.Unix(1700000000, 0)
+
+	sig := SignWebhook("secret", now, body)
+
+	if !strings.HasPrefix(sig, "t="+strconv.FormatInt(now.Unix(), 10)+",") {
+		t.Errorf("signature %q does not start with the timestamp", sig)
+	}
+
+	if !verifySignature("secret", sig, body) {
+		t.Errorf("signature %q does not verify", sig)
+	}
+
+	if verifySignature("other", sig, body) {
+		t.Error("signature verifies with the wrong secret")
+	}
+
+	if verifySignature("secret", sig, []byte(`{"type":"link.deleted"}`)) {
+		t.Error("signature verifies for a different body")
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	d := NewWebhookDispatcher(nil, http.DefaultClient, 1, 10, 10, time.Second, 10*time.Second)
+
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{4, 8 * time.Second},
+		{5, 10 * time.Second},
+		{50, 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := d.retryDelay(tt.attempts); got != tt.want {
+			t.Errorf("retryDelay(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestDeliverDue(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		attempts      int
+		wantStatus    string
+		wantDelivered int
+		wantRetry     bool
+	}{
+		{name: "success", status: http.StatusOK, wantStatus: model.DeliveryDelivered, wantDelivered: 1},
+		{name: "failure is retried", status: http.StatusInternalServerError, wantStatus: model.DeliveryPending, wantRetry: true},
+		{name: "redirect is a failure", status: http.StatusFound, wantStatus: model.DeliveryPending, wantRetry: true},
+		{name: "last attempt goes to the dead-letter queue", status: http.StatusInternalServerError, attempts: 2, wantStatus: model.DeliveryDead},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub := &model.WebhookSubscription{ID: uuid.New(), Secret: "secret"}
+			delivery := &model.WebhookDelivery{
+				ID:             uuid.New(),
+				SubscriptionID: sub.ID,
+				Event:          model.EventLinkCreated,
+				Payload:        []byte(`{"type":"link.created"}`),
+				Status:         model.DeliveryPending,
+				Attempts:       tt.attempts,
+			}
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+
+				if !verifySignature(sub.Secret, r.Header.Get(WebhookSignatureHeader), body) {
+					t.Error("receiver got a request with a bad signature")
+				}
+
+				if got := r.Header.Get(WebhookDeliveryHeader); got != delivery.ID.String() {
+					t.Errorf("delivery header = %q, want %q", got, delivery.ID)
+				}
+
+				if tt.status == http.StatusFound {
+					w.Header().Set("Location", "/elsewhere")
+				}
+
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			sub.URL = srv.URL
+			repo := &fakeWebhookRepo{sub: sub, deliveries: []*model.WebhookDelivery{delivery}}
+
+			d := NewWebhookDispatcher(repo, NewWebhookClient(time.Second, testPolicy()), 1, 10, 3, time.Minute, time.Hour)
+
+			delivered, failed, err := d.DeliverDue(context.Background())
+			if err != nil {
+				t.Fatalf("DeliverDue() error = %v", err)
+			}
+
+			if delivered != tt.wantDelivered || failed != 1-tt.wantDelivered {
+				t.Errorf("DeliverDue() = %d delivered, %d failed", delivered, failed)
+			}
+
+			if len(repo.attempts) != 1 {
+				t.Fatalf("recorded %d attempts, want 1", len(repo.attempts))
+			}
+
+			if got := repo.attempts[0]; got.Attempt != tt.attempts+1 || got.StatusCode != tt.status {
+				t.Errorf("attempt = #%d with status %d, want #%d with %d", got.Attempt, got.StatusCode, tt.attempts+1, tt.status)
+			}
+
+			if delivery.Status != tt.wantStatus {
+				t.Errorf("delivery status = %q, want %q", delivery.Status, tt.wantStatus)
+			}
+
+			if retry := !delivery.NextAttemptAt.IsZero(); retry != tt.wantRetry {
+				t.Errorf("next attempt scheduled = %v, want %v", retry, tt.wantRetry)
+			}
+		})
+	}
+}
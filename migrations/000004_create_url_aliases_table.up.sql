@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS url_aliases (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    url_id UUID NOT NULL REFERENCES urls(id) ON DELETE CASCADE,
+    short_code VARCHAR(20) UNIQUE NOT NULL,
+    clicks BIGINT DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT alias_short_code_length CHECK (LENGTH(short_code) >= 3)
+);
+
+CREATE INDEX IF NOT EXISTS idx_url_aliases_url_id ON url_aliases(url_id);
@@ -0,0 +1,7 @@
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS url_hash CHAR(64);
+
+UPDATE urls SET url_hash = encode(sha256(original_url::bytea), 'hex') WHERE url_hash IS NULL;
+
+ALTER TABLE urls ALTER COLUMN url_hash SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_url_hash ON urls(url_hash);
@@ -0,0 +1,5 @@
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS normalized_url TEXT;
+
+UPDATE urls SET normalized_url = original_url WHERE normalized_url IS NULL;
+
+ALTER TABLE urls ALTER COLUMN normalized_url SET NOT NULL;
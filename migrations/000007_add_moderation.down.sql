@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS banned_domains;
+
+DROP TABLE IF EXISTS reports;
+
+ALTER TABLE urls DROP COLUMN IF EXISTS status_reason;
+
+ALTER TABLE urls DROP COLUMN IF EXISTS status;
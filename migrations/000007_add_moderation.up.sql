@@ -0,0 +1,23 @@
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'active';
+
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS status_reason TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS reports (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    url_id UUID NOT NULL REFERENCES urls(id) ON DELETE CASCADE,
+    short_code VARCHAR(20) NOT NULL,
+    reason VARCHAR(32) NOT NULL,
+    details TEXT NOT NULL DEFAULT '',
+    reporter_ip VARCHAR(64) NOT NULL DEFAULT '',
+    status VARCHAR(16) NOT NULL DEFAULT 'open',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    resolved_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_reports_status ON reports(status, created_at);
+
+CREATE TABLE IF NOT EXISTS banned_domains (
+    domain VARCHAR(255) PRIMARY KEY,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
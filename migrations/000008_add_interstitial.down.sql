@@ -0,0 +1,3 @@
+ALTER TABLE urls DROP COLUMN IF EXISTS creator_trust;
+
+ALTER TABLE urls DROP COLUMN IF EXISTS interstitial;
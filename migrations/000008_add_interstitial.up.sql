@@ -0,0 +1,3 @@
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS interstitial BOOLEAN;
+
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS creator_trust VARCHAR(16) NOT NULL DEFAULT 'anonymous';
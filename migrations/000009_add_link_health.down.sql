@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_health_broken;
+
+DROP INDEX IF EXISTS idx_health_checked_at;
+
+ALTER TABLE urls DROP COLUMN IF EXISTS health_checked_at;
+
+ALTER TABLE urls DROP COLUMN IF EXISTS health;
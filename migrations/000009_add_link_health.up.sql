@@ -0,0 +1,7 @@
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS health JSONB;
+
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS health_checked_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_health_checked_at ON urls(health_checked_at NULLS FIRST);
+
+CREATE INDEX IF NOT EXISTS idx_health_broken ON urls(health_checked_at) WHERE (health->>'broken')::boolean;
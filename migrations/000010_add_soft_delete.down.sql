@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_deleted_at;
+
+ALTER TABLE urls DROP COLUMN IF EXISTS deleted_at;
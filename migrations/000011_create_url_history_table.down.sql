@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS url_history;
+
+DROP FUNCTION IF EXISTS prevent_url_history_change();
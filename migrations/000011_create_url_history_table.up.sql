@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS url_history (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    url_id UUID NOT NULL,
+    version INT NOT NULL,
+    action VARCHAR(16) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    actor_ip VARCHAR(64) NOT NULL DEFAULT '',
+    old_value JSONB,
+    new_value JSONB,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT url_history_version UNIQUE (url_id, version)
+);
+
+-- History outlives purged links, so it deliberately has no foreign key.
+CREATE OR REPLACE FUNCTION prevent_url_history_change() RETURNS TRIGGER AS $$ BEGIN RAISE EXCEPTION 'url_history is append-only';
+
+END;
+
+$$ language 'plpgsql';
+
+CREATE TRIGGER url_history_append_only BEFORE
+UPDATE
+    OR DELETE ON url_history FOR EACH ROW EXECUTE FUNCTION prevent_url_history_change();
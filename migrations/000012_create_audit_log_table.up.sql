@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS audit_log (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    request_id VARCHAR(128) NOT NULL DEFAULT '',
+    actor VARCHAR(255) NOT NULL,
+    ip VARCHAR(64) NOT NULL DEFAULT '',
+    method VARCHAR(16) NOT NULL,
+    path TEXT NOT NULL,
+    route TEXT NOT NULL DEFAULT '',
+    status INT NOT NULL,
+    outcome VARCHAR(16) NOT NULL,
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_created_at ON audit_log(created_at);
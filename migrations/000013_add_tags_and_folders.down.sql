@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS url_tags;
+
+DROP INDEX IF EXISTS idx_urls_folder;
+
+ALTER TABLE urls DROP COLUMN IF EXISTS folder;
@@ -0,0 +1,11 @@
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS folder VARCHAR(100) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_urls_folder ON urls(folder) WHERE folder <> '';
+
+CREATE TABLE IF NOT EXISTS url_tags (
+    url_id UUID NOT NULL REFERENCES urls(id) ON DELETE CASCADE,
+    tag VARCHAR(50) NOT NULL,
+    PRIMARY KEY (url_id, tag)
+);
+
+CREATE INDEX IF NOT EXISTS idx_url_tags_tag ON url_tags(tag);
@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS idx_url_aliases_domain_short_code;
+
+ALTER TABLE url_aliases ADD CONSTRAINT url_aliases_short_code_key UNIQUE (short_code);
+
+ALTER TABLE url_aliases DROP COLUMN IF EXISTS domain;
+
+DROP INDEX IF EXISTS idx_urls_domain_short_code;
+
+ALTER TABLE urls ADD CONSTRAINT urls_short_code_key UNIQUE (short_code);
+
+ALTER TABLE urls DROP COLUMN IF EXISTS domain;
+
+DROP TABLE IF EXISTS short_domains;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS short_domains (
+    host VARCHAR(253) PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- An empty domain is the default one served from APP_BASE_URL.
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS domain VARCHAR(253) NOT NULL DEFAULT '';
+
+ALTER TABLE urls DROP CONSTRAINT IF EXISTS urls_short_code_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_urls_domain_short_code ON urls(domain, short_code);
+
+ALTER TABLE url_aliases ADD COLUMN IF NOT EXISTS domain VARCHAR(253) NOT NULL DEFAULT '';
+
+ALTER TABLE url_aliases DROP CONSTRAINT IF EXISTS url_aliases_short_code_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_url_aliases_domain_short_code ON url_aliases(domain, short_code);
@@ -0,0 +1,5 @@
+ALTER TABLE short_domains DROP COLUMN IF EXISTS disabled_url;
+
+ALTER TABLE short_domains DROP COLUMN IF EXISTS expired_url;
+
+ALTER TABLE short_domains DROP COLUMN IF EXISTS not_found_url;
@@ -0,0 +1,5 @@
+ALTER TABLE short_domains ADD COLUMN IF NOT EXISTS not_found_url TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE short_domains ADD COLUMN IF NOT EXISTS expired_url TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE short_domains ADD COLUMN IF NOT EXISTS disabled_url TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+ALTER TABLE short_domains DROP COLUMN IF EXISTS catch_all_url;
+
+ALTER TABLE short_domains DROP COLUMN IF EXISTS root_url;
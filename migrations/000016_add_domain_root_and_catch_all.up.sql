@@ -0,0 +1,3 @@
+ALTER TABLE short_domains ADD COLUMN IF NOT EXISTS root_url TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE short_domains ADD COLUMN IF NOT EXISTS catch_all_url TEXT NOT NULL DEFAULT '';
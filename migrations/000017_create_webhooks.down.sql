@@ -0,0 +1,7 @@
+ALTER TABLE urls DROP COLUMN IF EXISTS expiry_notified_at;
+
+DROP TABLE IF EXISTS webhook_attempts;
+
+DROP TABLE IF EXISTS webhook_deliveries;
+
+DROP TABLE IF EXISTS webhook_subscriptions;
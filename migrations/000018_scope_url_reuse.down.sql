@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_urls_url_hash_stale;
+
+DROP INDEX IF EXISTS idx_urls_reuse;
+
+ALTER TABLE urls DROP COLUMN IF EXISTS url_hash_stale;
+
+ALTER TABLE urls DROP COLUMN IF EXISTS creator;
@@ -0,0 +1,11 @@
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS creator VARCHAR(255) NOT NULL DEFAULT '';
+
+-- Hashes written by 000005 are of the raw original_url rather than the
+-- normalized form; existing rows are rehashed by the application.
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS url_hash_stale BOOLEAN NOT NULL DEFAULT TRUE;
+
+ALTER TABLE urls ALTER COLUMN url_hash_stale SET DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_urls_reuse ON urls(domain, creator, url_hash);
+
+CREATE INDEX IF NOT EXISTS idx_urls_url_hash_stale ON urls(id) WHERE url_hash_stale;
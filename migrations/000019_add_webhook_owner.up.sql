@@ -0,0 +1,8 @@
+ALTER TABLE webhook_subscriptions ADD COLUMN IF NOT EXISTS owner VARCHAR(255) NOT NULL DEFAULT '';
+
+-- Subscriptions could only be created with the admin API key so far.
+UPDATE webhook_subscriptions SET owner = 'admin' WHERE owner = '';
+
+ALTER TABLE webhook_subscriptions ALTER COLUMN owner DROP DEFAULT;
+
+CREATE INDEX IF NOT EXISTS idx_webhook_subscriptions_owner ON webhook_subscriptions(owner);